@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"demo-notion-api/config"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNoCredentials      = errors.New("no credentials")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticator resolves the credential of a request to an identity
+type Authenticator interface {
+	Authenticate(credential string) (*Identity, error)
+}
+
+// NewAuthenticator builds the authenticators configured in an auth file.
+// It returns nil when file is nil, i.e. when authentication is disabled.
+func NewAuthenticator(file *config.AuthFile) (Authenticator, error) {
+	if file == nil {
+		return nil, nil
+	}
+
+	var chain chainAuthenticator
+	if len(file.APIKeys) > 0 {
+		chain.apiKeys = NewAPIKeyAuthenticator(file.APIKeys)
+	}
+	if file.JWT != nil {
+		jwtAuthenticator, err := NewJWTAuthenticator(*file.JWT)
+		if err != nil {
+			return nil, err
+		}
+		chain.jwt = jwtAuthenticator
+	}
+	return &chain, nil
+}
+
+// APIKeyAuthenticator accepts the static keys of the auth file
+type APIKeyAuthenticator struct {
+	keys []apiKey
+}
+
+type apiKey struct {
+	name string
+	hash [sha256.Size]byte
+}
+
+func NewAPIKeyAuthenticator(keys []config.APIKeyConfig) *APIKeyAuthenticator {
+	a := &APIKeyAuthenticator{}
+	for _, key := range keys {
+		a.keys = append(a.keys, apiKey{name: key.Name, hash: sha256.Sum256([]byte(key.Value()))})
+	}
+	return a
+}
+
+// Authenticate compares the hash of credential with every key in constant time
+func (a *APIKeyAuthenticator) Authenticate(credential string) (*Identity, error) {
+	if credential == "" {
+		return nil, ErrNoCredentials
+	}
+
+	hash := sha256.Sum256([]byte(credential))
+	var match *apiKey
+	for i := range a.keys {
+		if subtle.ConstantTimeCompare(hash[:], a.keys[i].hash[:]) == 1 {
+			match = &a.keys[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: unknown api key", ErrInvalidCredentials)
+	}
+
+	return &Identity{Subject: match.name, Method: MethodAPIKey}, nil
+}
+
+// chainAuthenticator sends credentials shaped like a JWT to the JWT
+// authenticator and anything else to the API keys
+type chainAuthenticator struct {
+	apiKeys *APIKeyAuthenticator
+	jwt     *JWTAuthenticator
+}
+
+func (a *chainAuthenticator) Authenticate(credential string) (*Identity, error) {
+	if credential == "" {
+		return nil, ErrNoCredentials
+	}
+
+	if strings.Count(credential, ".") == 2 && a.jwt != nil {
+		return a.jwt.Authenticate(credential)
+	}
+	if a.apiKeys != nil {
+		return a.apiKeys.Authenticate(credential)
+	}
+	return nil, fmt.Errorf("%w: expected a bearer token", ErrInvalidCredentials)
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"demo-notion-api/config"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signing algorithms accepted in bearer tokens
+const (
+	algHS256 = "HS256"
+	algRS256 = "RS256"
+)
+
+// clockSkew is tolerated on exp, nbf and iat
+const clockSkew = 30 * time.Second
+
+// JWTAuthenticator validates bearer tokens against the keys of a JWKS file
+type JWTAuthenticator struct {
+	config config.JWTConfig
+	keys   []signingKey
+	parser *jwt.Parser
+}
+
+// signingKey is a verification key of the JWKS file: an RSA public key for
+// RS256 or a shared secret for HS256
+type signingKey struct {
+	id  string
+	alg string
+	key any
+}
+
+// jsonWebKey holds the JWK members used for RSA and symmetric keys
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+func NewJWTAuthenticator(cfg config.JWTConfig) (*JWTAuthenticator, error) {
+	keys, err := loadJWKS(cfg.JWKSFile)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{algHS256, algRS256}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &JWTAuthenticator{
+		config: cfg,
+		keys:   keys,
+		parser: jwt.NewParser(options...),
+	}, nil
+}
+
+// Authenticate verifies the signature and claims of a token and names the
+// caller after its subject claim
+func (a *JWTAuthenticator) Authenticate(credential string) (*Identity, error) {
+	if credential == "" {
+		return nil, ErrNoCredentials
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := a.parser.ParseWithClaims(credential, claims, a.key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	subject, _ := claims[a.config.Subject()].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: token has no %s claim", ErrInvalidCredentials, a.config.Subject())
+	}
+
+	return &Identity{Subject: subject, Method: MethodJWT, Claims: claims}, nil
+}
+
+// key picks the verification key by the kid header, or the only key of the
+// token's algorithm when there is no kid. The algorithm must match the key,
+// so an RSA public key can never be used as an HMAC secret.
+func (a *JWTAuthenticator) key(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	alg := token.Method.Alg()
+
+	var candidates []signingKey
+	for _, key := range a.keys {
+		if key.alg != alg || (kid != "" && key.id != kid) {
+			continue
+		}
+		candidates = append(candidates, key)
+	}
+
+	switch {
+	case len(candidates) == 1:
+		return candidates[0].key, nil
+	case len(candidates) == 0:
+		return nil, fmt.Errorf("no %s key with id %q", alg, kid)
+	default:
+		return nil, errors.New("token has no kid and several keys match")
+	}
+}
+
+// Helper functions
+
+// loadJWKS reads the RSA and symmetric signing keys of a JWKS file.
+// Encryption keys and other key types are ignored.
+func loadJWKS(path string) ([]signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks file: %w", err)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse jwks file %s: %w", path, err)
+	}
+
+	var keys []signingKey
+	for i, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := parseJWK(jwk)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %d in jwks file %s: %w", i, path, err)
+		}
+		if key != nil {
+			keys = append(keys, *key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("jwks file %s has no RS256 or HS256 signing keys", path)
+	}
+	return keys, nil
+}
+
+func parseJWK(jwk jsonWebKey) (*signingKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		if jwk.Alg != "" && jwk.Alg != algRS256 {
+			return nil, nil
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil || len(n) == 0 {
+			return nil, errors.New("modulus n is not base64url")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 {
+			return nil, errors.New("exponent e is not base64url")
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("exponent e is too large")
+		}
+		return &signingKey{
+			id:  jwk.Kid,
+			alg: algRS256,
+			key: &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())},
+		}, nil
+
+	case "oct":
+		if jwk.Alg != "" && jwk.Alg != algHS256 {
+			return nil, nil
+		}
+		secret, err := base64.RawURLEncoding.DecodeString(jwk.K)
+		if err != nil || len(secret) == 0 {
+			return nil, errors.New("secret k is not base64url")
+		}
+		return &signingKey{id: jwk.Kid, alg: algHS256, key: secret}, nil
+	}
+
+	return nil, nil
+}
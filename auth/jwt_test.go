@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"demo-notion-api/config"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	testSecret      = []byte("0123456789abcdef0123456789abcdef")
+	testOtherSecret = []byte("fedcba9876543210fedcba9876543210")
+)
+
+// writeJWKS writes a JWKS file with an RSA key, two HMAC secrets and an
+// encryption key that must be ignored
+func writeJWKS(t *testing.T, rsaKey *rsa.PrivateKey) string {
+	t.Helper()
+
+	b64 := base64.RawURLEncoding.EncodeToString
+	set := map[string]any{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "alg": "RS256", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "oct", "kid": "hs-1", "alg": "HS256", "k": b64(testSecret)},
+		{"kty": "oct", "kid": "hs-2", "k": b64(testOtherSecret)},
+		{"kty": "oct", "kid": "enc-1", "use": "enc", "k": b64([]byte("encryption key"))},
+	}}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := NewJWTAuthenticator(config.JWTConfig{
+		JWKSFile: writeJWKS(t, rsaKey),
+		Issuer:   "https://issuer.example",
+		Audience: "demo-notion-api",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	claims := func(changes map[string]any) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub": "ci",
+			"iss": "https://issuer.example",
+			"aud": "demo-notion-api",
+			"exp": now.Add(time.Hour).Unix(),
+		}
+		for name, value := range changes {
+			if value == nil {
+				delete(c, name)
+			} else {
+				c[name] = value
+			}
+		}
+		return c
+	}
+	sign := func(method jwt.SigningMethod, kid string, c jwt.MapClaims, key any) string {
+		token := jwt.NewWithClaims(method, c)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	rsaPublicKey := rsaKey.N.Bytes()
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "RS256 with kid", token: sign(jwt.SigningMethodRS256, "rsa-1", claims(nil), rsaKey)},
+		{name: "RS256 without kid uses the only RSA key", token: sign(jwt.SigningMethodRS256, "", claims(nil), rsaKey)},
+		{name: "HS256 with kid", token: sign(jwt.SigningMethodHS256, "hs-1", claims(nil), testSecret)},
+		{name: "HS256 key without alg", token: sign(jwt.SigningMethodHS256, "hs-2", claims(nil), testOtherSecret)},
+		{name: "expiry within the clock skew", token: sign(jwt.SigningMethodHS256, "hs-1", claims(map[string]any{"exp": now.Add(-10 * time.Second).Unix()}), testSecret)},
+
+		{name: "alg none", token: sign(jwt.SigningMethodNone, "", claims(nil), jwt.UnsafeAllowNoneSignatureType), wantErr: true},
+		{name: "HS512 is not accepted", token: sign(jwt.SigningMethodHS512, "hs-1", claims(nil), testSecret), wantErr: true},
+		{name: "HS256 signed with the RSA public key", token: sign(jwt.SigningMethodHS256, "rsa-1", claims(nil), rsaPublicKey), wantErr: true},
+		{name: "HS256 without kid matches several keys", token: sign(jwt.SigningMethodHS256, "", claims(nil), testSecret), wantErr: true},
+		{name: "unknown kid", token: sign(jwt.SigningMethodHS256, "hs-9", claims(nil), testSecret), wantErr: true},
+		{name: "encryption keys are ignored", token: sign(jwt.SigningMethodHS256, "enc-1", claims(nil), []byte("encryption key")), wantErr: true},
+		{name: "kid of another secret", token: sign(jwt.SigningMethodHS256, "hs-2", claims(nil), testSecret), wantErr: true},
+		{name: "signed by another RSA key", token: sign(jwt.SigningMethodRS256, "rsa-1", claims(nil), otherRSAKey), wantErr: true},
+		{name: "expired", token: sign(jwt.SigningMethodHS256, "hs-1", claims(map[string]any{"exp": now.Add(-time.Minute).Unix()}), testSecret), wantErr: true},
+		{name: "no expiry", token: sign(jwt.SigningMethodHS256, "hs-1", claims(map[string]any{"exp": nil}), testSecret), wantErr: true},
+		{name: "not valid yet", token: sign(jwt.SigningMethodHS256, "hs-1", claims(map[string]any{"nbf": now.Add(time.Minute).Unix()}), testSecret), wantErr: true},
+		{name: "wrong issuer", token: sign(jwt.SigningMethodHS256, "hs-1", claims(map[string]any{"iss": "https://other.example"}), testSecret), wantErr: true},
+		{name: "wrong audience", token: sign(jwt.SigningMethodHS256, "hs-1", claims(map[string]any{"aud": "other"}), testSecret), wantErr: true},
+		{name: "no subject", token: sign(jwt.SigningMethodHS256, "hs-1", claims(map[string]any{"sub": nil}), testSecret), wantErr: true},
+		{name: "not a token", token: "a.b.c", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := authenticator.Authenticate(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCredentials) {
+					t.Errorf("Authenticate() error = %v, want ErrInvalidCredentials", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if identity.Subject != "ci" || identity.Method != MethodJWT {
+				t.Errorf("identity = %+v, want subject ci by jwt", identity)
+			}
+		})
+	}
+
+	if _, err := authenticator.Authenticate(""); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Authenticate(\"\") error = %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestJWTAuthenticatorSubjectClaim(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticator, err := NewJWTAuthenticator(config.JWTConfig{JWKSFile: writeJWKS(t, rsaKey), SubjectClaim: "client_id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":       "user",
+		"client_id": "ci",
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "hs-1"
+	signed, err := token.SignedString(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := authenticator.Authenticate(signed)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if identity.Subject != "ci" {
+		t.Errorf("subject = %q, want the client_id claim", identity.Subject)
+	}
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries a static API key as an alternative to a bearer token
+const APIKeyHeader = "X-API-Key"
+
+// Middleware rejects requests without valid credentials with 401 and stores
+// the identity of the caller in the request context. Credentials are read from
+// "Authorization: Bearer <token>" or the X-API-Key header.
+func Middleware(authenticator Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credential := Credential(c.GetHeader("Authorization"), c.GetHeader(APIKeyHeader))
+
+		identity, err := authenticator.Authenticate(credential)
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "authentication failed",
+				slog.String("path", c.Request.URL.Path),
+				slog.String("client_ip", c.ClientIP()),
+				slog.Any("error", err),
+			)
+
+			message := "A valid API key or bearer token is required"
+			if !errors.Is(err, ErrNoCredentials) {
+				message = err.Error()
+			}
+			c.Header("WWW-Authenticate", `Bearer realm="demo-notion-api"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": message,
+			})
+			return
+		}
+
+		slog.DebugContext(c.Request.Context(), "authenticated",
+			slog.String("subject", identity.Subject),
+			slog.String("method", identity.Method),
+		)
+		c.Request = c.Request.WithContext(WithIdentity(c.Request.Context(), identity))
+		c.Next()
+	}
+}
+
+// OptionalMiddleware stores the identity of callers that send valid
+// credentials and lets every other request through anonymously, for public
+// routes that show more to known callers. A nil authenticator stores nothing.
+func OptionalMiddleware(authenticator Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credential := Credential(c.GetHeader("Authorization"), c.GetHeader(APIKeyHeader))
+		if authenticator == nil || credential == "" {
+			c.Next()
+			return
+		}
+
+		if identity, err := authenticator.Authenticate(credential); err == nil {
+			c.Request = c.Request.WithContext(WithIdentity(c.Request.Context(), identity))
+		}
+		c.Next()
+	}
+}
+
+// Credential returns the token of a bearer Authorization header, or else the API key header
+func Credential(authorization, apiKey string) string {
+	if scheme, token, ok := strings.Cut(authorization, " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return strings.TrimSpace(apiKey)
+}
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"demo-notion-api/config"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Roles, each allowed everything the roles before it are
+const (
+	// RoleViewer reads test cases, runs and reports
+	RoleViewer = "viewer"
+	// RoleTester also updates statuses and step results
+	RoleTester = "tester"
+	// RoleLead also creates and deletes test cases and manages runs
+	RoleLead = "lead"
+)
+
+var roleRanks = map[string]int{
+	RoleViewer: 1,
+	RoleTester: 2,
+	RoleLead:   3,
+}
+
+// anySubject binds a role to every authenticated caller
+const anySubject = "*"
+
+// Policy decides which role a caller has in a project
+type Policy struct {
+	bindings   []config.RoleBinding
+	databaseOf func(project string) string
+}
+
+// NewPolicy builds the policy of a policy file. databaseOf returns the Notion
+// database of a project, for bindings scoped to databases. It returns nil when
+// file is nil, which allows every authenticated caller everything.
+func NewPolicy(file *config.PolicyFile, databaseOf func(project string) string) (*Policy, error) {
+	if file == nil {
+		return nil, nil
+	}
+
+	for i, binding := range file.Bindings {
+		if _, ok := roleRanks[binding.Role]; !ok {
+			return nil, fmt.Errorf("binding %d has unknown role %q; use %s, %s or %s", i, binding.Role, RoleViewer, RoleTester, RoleLead)
+		}
+	}
+
+	return &Policy{
+		bindings:   file.Bindings,
+		databaseOf: databaseOf,
+	}, nil
+}
+
+// Role returns the highest role of subject in project, or "" when it has none.
+// The empty project stands for every project and is only covered by global bindings.
+func (p *Policy) Role(subject, project string) string {
+	role := ""
+	for _, binding := range p.bindings {
+		if binding.Subject != subject && binding.Subject != anySubject {
+			continue
+		}
+		if !p.covers(binding, project) {
+			continue
+		}
+		if roleRanks[binding.Role] > roleRanks[role] {
+			role = binding.Role
+		}
+	}
+	return role
+}
+
+// Allows reports whether subject has at least role in project
+func (p *Policy) Allows(subject, role, project string) bool {
+	if p == nil {
+		return true
+	}
+	return roleRanks[p.Role(subject, project)] >= roleRanks[role]
+}
+
+// Scope returns the project a request works on; "" means every project
+type Scope func(c *gin.Context) string
+
+// ProjectScope is the :project route parameter, or defaultProject on unscoped routes
+func ProjectScope(defaultProject string) Scope {
+	return func(c *gin.Context) string {
+		if project := c.Param("project"); project != "" {
+			return project
+		}
+		return defaultProject
+	}
+}
+
+// AllProjects is the scope of routes that read across projects
+func AllProjects(c *gin.Context) string {
+	return ""
+}
+
+// Require rejects requests whose caller lacks role in the scope of the
+// request with 403. A nil policy lets every request through.
+func (p *Policy) Require(role string, scope Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if p == nil {
+			c.Next()
+			return
+		}
+
+		identity, ok := IdentityFrom(c.Request.Context())
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "Unauthorized",
+				"message": "A valid API key or bearer token is required",
+			})
+			return
+		}
+
+		project := scope(c)
+		if !p.Allows(identity.Subject, role, project) {
+			slog.WarnContext(c.Request.Context(), "access denied",
+				slog.String("subject", identity.Subject),
+				slog.String("role", role),
+				slog.String("scope", scopeName(project)),
+				slog.String("path", c.Request.URL.Path),
+			)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": fmt.Sprintf("%s role required in %s", role, scopeName(project)),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// Helper methods
+
+func (p *Policy) covers(binding config.RoleBinding, project string) bool {
+	if binding.Global() {
+		return true
+	}
+	if project == "" {
+		return false
+	}
+	if slices.Contains(binding.Projects, project) {
+		return true
+	}
+	database := normalizeID(p.databaseOf(project))
+	if database == "" {
+		return false
+	}
+	return slices.ContainsFunc(binding.Databases, func(id string) bool {
+		return normalizeID(id) == database
+	})
+}
+
+// Helper functions
+
+// normalizeID lets database IDs be written with or without dashes
+func normalizeID(id string) string {
+	return strings.ToLower(strings.ReplaceAll(id, "-", ""))
+}
+
+func scopeName(project string) string {
+	if project == "" {
+		return "all projects"
+	}
+	return "project " + project
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"demo-notion-api/config"
+	"strings"
+	"testing"
+)
+
+func TestPolicyRole(t *testing.T) {
+	databases := map[string]string{
+		"web":    "0a1b2c3d-4e5f-6071-8293-a4b5c6d7e8f9",
+		"mobile": "ffffffff-0000-0000-0000-000000000000",
+	}
+	policy, err := NewPolicy(&config.PolicyFile{Bindings: []config.RoleBinding{
+		{Subject: "ann", Role: RoleViewer},
+		{Subject: "ann", Role: RoleLead, Projects: []string{"web"}},
+		{Subject: "bob", Role: RoleTester, Databases: []string{"0A1B2C3D4E5F60718293A4B5C6D7E8F9"}},
+		{Subject: "cid", Role: RoleLead, Projects: []string{"api"}},
+		{Subject: "cid", Role: RoleViewer, Projects: []string{"api"}},
+		{Subject: anySubject, Role: RoleViewer, Projects: []string{"mobile"}},
+	}}, func(project string) string { return databases[project] })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		subject string
+		project string
+		want    string
+	}{
+		{name: "global binding", subject: "ann", project: "mobile", want: RoleViewer},
+		{name: "highest role wins", subject: "ann", project: "web", want: RoleLead},
+		{name: "lower role later in the file", subject: "cid", project: "api", want: RoleLead},
+		{name: "database written without dashes in upper case", subject: "bob", project: "web", want: RoleTester},
+		{name: "other database", subject: "bob", project: "api", want: ""},
+		{name: "project outside the binding", subject: "cid", project: "web", want: ""},
+		{name: "every caller", subject: "dan", project: "mobile", want: RoleViewer},
+		{name: "every caller only where bound", subject: "dan", project: "web", want: ""},
+		{name: "all projects through a global binding", subject: "ann", project: "", want: RoleViewer},
+		{name: "all projects are not covered by project bindings", subject: "cid", project: "", want: ""},
+		{name: "all projects are not covered by database bindings", subject: "bob", project: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Role(tt.subject, tt.project); got != tt.want {
+				t.Errorf("Role(%q, %q) = %q, want %q", tt.subject, tt.project, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyAllows(t *testing.T) {
+	policy, err := NewPolicy(&config.PolicyFile{Bindings: []config.RoleBinding{
+		{Subject: "ann", Role: RoleTester, Projects: []string{"web"}},
+	}}, func(string) string { return "" })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		policy  *Policy
+		subject string
+		role    string
+		project string
+		want    bool
+	}{
+		{name: "lower role", policy: policy, subject: "ann", role: RoleViewer, project: "web", want: true},
+		{name: "same role", policy: policy, subject: "ann", role: RoleTester, project: "web", want: true},
+		{name: "higher role", policy: policy, subject: "ann", role: RoleLead, project: "web", want: false},
+		{name: "other project", policy: policy, subject: "ann", role: RoleViewer, project: "mobile", want: false},
+		{name: "unknown subject", policy: policy, subject: "bob", role: RoleViewer, project: "web", want: false},
+		{name: "nil policy allows everything", policy: nil, subject: "bob", role: RoleLead, project: "", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Allows(tt.subject, tt.role, tt.project); got != tt.want {
+				t.Errorf("Allows(%q, %q, %q) = %v, want %v", tt.subject, tt.role, tt.project, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	policy, err := NewPolicy(nil, nil)
+	if policy != nil || err != nil {
+		t.Errorf("NewPolicy(nil) = %v, %v, want nil, nil", policy, err)
+	}
+
+	_, err = NewPolicy(&config.PolicyFile{Bindings: []config.RoleBinding{
+		{Subject: "ann", Role: RoleViewer},
+		{Subject: "bob", Role: "admin"},
+	}}, nil)
+	if err == nil || !strings.Contains(err.Error(), `binding 1 has unknown role "admin"`) {
+		t.Errorf("NewPolicy() error = %v, want it to reject the unknown role", err)
+	}
+}
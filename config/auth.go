@@ -0,0 +1,102 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// AuthFile lists the credentials accepted by the API
+type AuthFile struct {
+	APIKeys []APIKeyConfig `json:"api_keys,omitempty"`
+	JWT     *JWTConfig     `json:"jwt,omitempty"`
+}
+
+// APIKeyConfig is a static API key. The key is read from KeyEnv when set, so
+// it does not have to be stored in the auth file.
+type APIKeyConfig struct {
+	Name   string `json:"name"`
+	Key    string `json:"key,omitempty"`
+	KeyEnv string `json:"key_env,omitempty"`
+}
+
+// JWTConfig validates bearer tokens signed with HS256 or RS256 by the keys of a
+// local JWKS file. Issuer and audience are only checked when set.
+type JWTConfig struct {
+	JWKSFile     string `json:"jwks_file"`
+	Issuer       string `json:"issuer,omitempty"`
+	Audience     string `json:"audience,omitempty"`
+	SubjectClaim string `json:"subject_claim,omitempty"`
+}
+
+// LoadAuth reads and validates the auth file. Unlike the other config files,
+// a missing auth file is an error, so that a mistyped path cannot leave the
+// API open; running without authentication needs AUTH_DISABLED instead.
+func LoadAuth(path string) (*AuthFile, error) {
+	if path == "" {
+		return nil, fmt.Errorf("no auth file configured; set AUTH_DISABLED=true to run without authentication")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("auth file %s not found; set AUTH_DISABLED=true to run without authentication", path)
+		}
+		return nil, fmt.Errorf("failed to read auth file: %w", err)
+	}
+
+	var file AuthFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse auth file %s: %w", path, err)
+	}
+
+	if err := file.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid auth file %s: %w", path, err)
+	}
+
+	return &file, nil
+}
+
+// Validate checks that API keys are named uniquely and have a value
+func (f *AuthFile) Validate() error {
+	if len(f.APIKeys) == 0 && f.JWT == nil {
+		return errors.New("at least one api key or a jwt section is required")
+	}
+
+	names := make(map[string]bool)
+	for _, key := range f.APIKeys {
+		if key.Name == "" {
+			return errors.New("api key name is required")
+		}
+		if names[key.Name] {
+			return fmt.Errorf("duplicate api key %q", key.Name)
+		}
+		names[key.Name] = true
+
+		if key.Value() == "" {
+			return fmt.Errorf("api key %q has no key and %q is not set", key.Name, key.KeyEnv)
+		}
+	}
+
+	if f.JWT != nil && f.JWT.JWKSFile == "" {
+		return errors.New("jwt.jwks_file is required")
+	}
+	return nil
+}
+
+// Value returns the key, read from the KeyEnv environment variable when set
+func (k APIKeyConfig) Value() string {
+	if k.KeyEnv != "" {
+		return os.Getenv(k.KeyEnv)
+	}
+	return k.Key
+}
+
+// Subject returns the claim naming the caller, "sub" by default
+func (j JWTConfig) Subject() string {
+	if j.SubjectClaim == "" {
+		return "sub"
+	}
+	return j.SubjectClaim
+}
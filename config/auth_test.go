@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadAuth(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	t.Setenv("TEST_API_KEY", "secret")
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{name: "api key from the environment", path: write("env.json", `{"api_keys": [{"name": "ci", "key_env": "TEST_API_KEY"}]}`)},
+		{name: "jwt only", path: write("jwt.json", `{"jwt": {"jwks_file": "jwks.json"}}`)},
+		{name: "no path", path: "", wantErr: "AUTH_DISABLED=true"},
+		{name: "missing file", path: filepath.Join(dir, "missing.json"), wantErr: "AUTH_DISABLED=true"},
+		{name: "malformed", path: write("bad.json", `{`), wantErr: "failed to parse"},
+		{name: "empty", path: write("empty.json", `{}`), wantErr: "at least one api key"},
+		{name: "duplicate key names", path: write("dup.json", `{"api_keys": [{"name": "ci", "key": "a"}, {"name": "ci", "key": "b"}]}`), wantErr: "duplicate api key"},
+		{name: "unset key variable", path: write("unset.json", `{"api_keys": [{"name": "ci", "key_env": "TEST_UNSET_KEY"}]}`), wantErr: "is not set"},
+		{name: "jwt without jwks file", path: write("nojwks.json", `{"jwt": {}}`), wantErr: "jwks_file is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := LoadAuth(tt.path)
+			if tt.wantErr == "" {
+				if err != nil || file == nil {
+					t.Fatalf("LoadAuth() = %v, %v, want a file", file, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadAuth() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAllowedOrigins(t *testing.T) {
+	tests := []struct {
+		name         string
+		origins      string
+		authDisabled bool
+		want         []string
+	}{
+		{name: "no wildcard with authentication", want: nil},
+		{name: "wildcard without authentication", authDisabled: true, want: []string{"*"}},
+		{name: "configured origins", origins: " https://a.example, ,https://b.example", want: []string{"https://a.example", "https://b.example"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{CORSOrigins: tt.origins, AuthDisabled: tt.authDisabled}
+			if got := cfg.AllowedOrigins(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AllowedOrigins() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
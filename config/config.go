@@ -2,12 +2,43 @@ package config
 
 import (
 	"os"
+	"strings"
 )
 
 type Config struct {
 	NotionAPIKey     string
 	NotionAPIVersion string
 	NotionAPIURL     string
+	NotionDatabaseID string
+	SuitesFile       string
+	SchemaFile       string
+	ProjectsFile     string
+	DataDir          string
+	SyncInterval     string
+	ReadyMaxSyncAge  string
+	ReadyCacheTTL    string
+	AuthFile         string
+	AuthDisabled     bool
+	PolicyFile       string
+	CORSOrigins      string
+	TrustedProxies   string
+	LogLevel         string
+	LogFormat        string
+	TraceExporter    string
+	TraceEndpoint    string
+	TraceFile        string
+
+	// Rate limits per caller as "<count>/<s|m|h>"; expensive routes have their own buckets
+	RateLimit               string
+	RateLimitBurst          string
+	ExpensiveRateLimit      string
+	ExpensiveRateLimitBurst string
+
+	// ProjectName is set on per-project copies of the config
+	ProjectName string
+
+	// Schema is loaded from SchemaFile at startup; nil means DefaultSchema
+	Schema *Schema
 }
 
 func Load() *Config {
@@ -15,7 +46,56 @@ func Load() *Config {
 		NotionAPIKey:     getEnv("NOTION_API_KEY", ""),
 		NotionAPIVersion: getEnv("NOTION_VERSION", "2022-06-28"),
 		NotionAPIURL:     getEnv("NOTION_API_URL", "https://api.notion.com/v1"),
+		NotionDatabaseID: getEnv("NOTION_DATABASE_ID", ""),
+		SuitesFile:       getEnv("SUITES_FILE", "suites.json"),
+		SchemaFile:       getEnv("SCHEMA_FILE", "schema.json"),
+		ProjectsFile:     getEnv("PROJECTS_FILE", "projects.json"),
+		DataDir:          getEnv("DATA_DIR", "data"),
+		SyncInterval:     getEnv("SYNC_INTERVAL", "5m"),
+		ReadyMaxSyncAge:  getEnv("READY_MAX_SYNC_AGE", "15m"),
+		ReadyCacheTTL:    getEnv("READY_CACHE_TTL", "15s"),
+		AuthFile:         getEnv("AUTH_FILE", "auth.json"),
+		AuthDisabled:     getEnv("AUTH_DISABLED", "false") == "true",
+		PolicyFile:       getEnv("POLICY_FILE", "policy.json"),
+		CORSOrigins:      getEnv("CORS_ALLOWED_ORIGINS", ""),
+		TrustedProxies:   getEnv("TRUSTED_PROXIES", ""),
+		LogLevel:         getEnv("LOG_LEVEL", "info"),
+		LogFormat:        getEnv("LOG_FORMAT", "json"),
+		TraceExporter:    getEnv("TRACE_EXPORTER", "none"),
+		TraceEndpoint:    getEnv("TRACE_OTLP_ENDPOINT", "http://localhost:4318/v1/traces"),
+		TraceFile:        getEnv("TRACE_FILE", "traces.jsonl"),
+
+		RateLimit:               getEnv("RATE_LIMIT", "120/m"),
+		RateLimitBurst:          getEnv("RATE_LIMIT_BURST", "30"),
+		ExpensiveRateLimit:      getEnv("EXPENSIVE_RATE_LIMIT", "10/m"),
+		ExpensiveRateLimitBurst: getEnv("EXPENSIVE_RATE_LIMIT_BURST", "3"),
+	}
+}
+
+// AllowedOrigins splits the comma-separated CORS_ALLOWED_ORIGINS. Without
+// any, every origin is allowed only when authentication is disabled.
+func (c *Config) AllowedOrigins() []string {
+	origins := splitList(c.CORSOrigins)
+	if len(origins) == 0 && c.AuthDisabled {
+		return []string{"*"}
+	}
+	return origins
+}
+
+// TrustedProxyList splits the comma-separated TRUSTED_PROXIES; nil when none
+// are configured, so that forwarded headers are never trusted by default
+func (c *Config) TrustedProxyList() []string {
+	return splitList(c.TrustedProxies)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
 
 func getEnv(key, defaultValue string) string {
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// PolicyFile grants roles to authenticated callers
+type PolicyFile struct {
+	Bindings []RoleBinding `json:"bindings"`
+}
+
+// RoleBinding grants Role to the caller named Subject, or to every caller when
+// Subject is "*". Without projects and databases the role applies everywhere;
+// otherwise only to the listed projects and to projects on the listed databases.
+type RoleBinding struct {
+	Subject   string   `json:"subject"`
+	Role      string   `json:"role"`
+	Projects  []string `json:"projects,omitempty"`
+	Databases []string `json:"databases,omitempty"`
+}
+
+// LoadPolicy reads and validates the policy file.
+// A missing file is not an error; nil is returned and roles are not checked.
+func LoadPolicy(path string) (*PolicyFile, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var file PolicyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+
+	if err := file.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+
+	return &file, nil
+}
+
+// Validate checks that every binding names a subject and a role
+func (f *PolicyFile) Validate() error {
+	if len(f.Bindings) == 0 {
+		return errors.New("at least one binding is required")
+	}
+
+	for i, binding := range f.Bindings {
+		if binding.Subject == "" {
+			return fmt.Errorf("binding %d has no subject", i)
+		}
+		if binding.Role == "" {
+			return fmt.Errorf("binding %d has no role", i)
+		}
+	}
+	return nil
+}
+
+// Global reports whether the binding is not limited to projects or databases
+func (b RoleBinding) Global() bool {
+	return len(b.Projects) == 0 && len(b.Databases) == 0
+}
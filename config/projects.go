@@ -0,0 +1,94 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// DefaultProjectName is the name of the project built from environment
+// variables when no projects file is present
+const DefaultProjectName = "default"
+
+// ProjectsFile represents the projects definition file
+type ProjectsFile struct {
+	// DefaultProject serves the unscoped /api/test-cases routes; the first
+	// project is used when it is empty
+	DefaultProject string          `json:"default_project"`
+	Projects       []ProjectConfig `json:"projects"`
+}
+
+// ProjectConfig describes one Notion workspace and database.
+// The token is read from APIKeyEnv when set, so it does not have to be
+// stored in the projects file.
+type ProjectConfig struct {
+	Name       string `json:"name"`
+	APIKey     string `json:"api_key,omitempty"`
+	APIKeyEnv  string `json:"api_key_env,omitempty"`
+	DatabaseID string `json:"database_id,omitempty"`
+	SchemaFile string `json:"schema_file,omitempty"`
+}
+
+// LoadProjects reads and validates the projects file.
+// A missing file is not an error; nil is returned.
+func LoadProjects(path string) (*ProjectsFile, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read projects file: %w", err)
+	}
+
+	var file ProjectsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse projects file %s: %w", path, err)
+	}
+
+	if err := file.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid projects file %s: %w", path, err)
+	}
+
+	return &file, nil
+}
+
+// Validate checks that projects are named uniquely and have a token
+func (f *ProjectsFile) Validate() error {
+	if len(f.Projects) == 0 {
+		return errors.New("at least one project is required")
+	}
+
+	names := make(map[string]bool)
+	for _, project := range f.Projects {
+		if project.Name == "" {
+			return errors.New("project name is required")
+		}
+		if names[project.Name] {
+			return fmt.Errorf("duplicate project %q", project.Name)
+		}
+		names[project.Name] = true
+
+		if project.Token() == "" {
+			return fmt.Errorf("project %q has no api_key and %q is not set", project.Name, project.APIKeyEnv)
+		}
+	}
+
+	if f.DefaultProject != "" && !names[f.DefaultProject] {
+		return fmt.Errorf("default_project %q is not defined", f.DefaultProject)
+	}
+
+	return nil
+}
+
+// Token returns the Notion API key of the project
+func (p ProjectConfig) Token() string {
+	if p.APIKeyEnv != "" {
+		return os.Getenv(p.APIKeyEnv)
+	}
+	return p.APIKey
+}
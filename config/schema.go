@@ -0,0 +1,216 @@
+package config
+
+import (
+	"demo-notion-api/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Schema describes how test cases are laid out in a Notion database
+type Schema struct {
+	TitleProperty    string `json:"title_property"`
+	StatusProperty   string `json:"status_property"`
+	StatusType       string `json:"status_type"`
+	DateProperty     string `json:"date_property"`
+	TagsProperty     string `json:"tags_property"`
+	AssigneeProperty string `json:"assignee_property"`
+	KeyPattern       string `json:"key_pattern"`
+	KeyGroup         int    `json:"key_group"`
+
+	// StepColumns maps a step field (number, action, expected_result,
+	// actual_result, status, screenshot) to the column names that hold it.
+	// Fields that are not listed keep their default column names.
+	StepColumns map[string][]string `json:"step_columns,omitempty"`
+
+	// StatusOutcomes maps an outcome (passed, failed, blocked, skipped,
+	// in_progress) to the status names that count as that outcome.
+	// Outcomes that are not listed keep their default status names.
+	StatusOutcomes map[string][]string `json:"status_outcomes,omitempty"`
+
+	keyRegexp *regexp.Regexp
+}
+
+// StepFields lists the step fields that StepColumns may configure
+var StepFields = []string{
+	"number",
+	"action",
+	"expected_result",
+	"actual_result",
+	"status",
+	"screenshot",
+}
+
+var defaultStatusOutcomes = map[string][]string{
+	models.OutcomePassed:     {"Passed", "Pass", "Done"},
+	models.OutcomeFailed:     {"Failed", "Fail"},
+	models.OutcomeBlocked:    {"Blocked"},
+	models.OutcomeSkipped:    {"Skipped", "Skip", "N/A"},
+	models.OutcomeInProgress: {"In progress"},
+}
+
+var outcomeOrder = []string{
+	models.OutcomePassed,
+	models.OutcomeFailed,
+	models.OutcomeBlocked,
+	models.OutcomeSkipped,
+	models.OutcomeInProgress,
+}
+
+// DefaultSchema returns the schema of the original CMS test case database
+func DefaultSchema() *Schema {
+	schema := &Schema{
+		TitleProperty:    "Test Case Name",
+		StatusProperty:   "Status",
+		StatusType:       "status",
+		DateProperty:     "Test Date",
+		TagsProperty:     "Tags",
+		AssigneeProperty: "Assignee",
+		KeyPattern:       `TC_(\d+)`,
+		KeyGroup:         1,
+	}
+	schema.keyRegexp = regexp.MustCompile(schema.KeyPattern)
+	return schema
+}
+
+// LoadSchema reads a schema file on top of DefaultSchema and validates it.
+// A missing file is not an error; the default schema is returned.
+func LoadSchema(path string) (*Schema, error) {
+	if path == "" {
+		return DefaultSchema(), nil
+	}
+
+	schema, err := LoadSchemaFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultSchema(), nil
+	}
+	return schema, err
+}
+
+// LoadSchemaFile is LoadSchema for a file that must exist, such as one named
+// explicitly by a project
+func LoadSchemaFile(path string) (*Schema, error) {
+	schema := DefaultSchema()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, fmt.Errorf("failed to parse schema file %s: %w", path, err)
+	}
+
+	if err := schema.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid schema file %s: %w", path, err)
+	}
+
+	return schema, nil
+}
+
+// Validate checks required properties and compiles the key pattern
+func (s *Schema) Validate() error {
+	if s.TitleProperty == "" {
+		return errors.New("title_property is required")
+	}
+	if s.KeyPattern == "" {
+		return errors.New("key_pattern is required")
+	}
+
+	if s.StatusType != "status" && s.StatusType != "select" {
+		return fmt.Errorf("status_type must be status or select, got %q", s.StatusType)
+	}
+
+	keyRegexp, err := regexp.Compile(s.KeyPattern)
+	if err != nil {
+		return fmt.Errorf("key_pattern: %w", err)
+	}
+	if s.KeyGroup < 0 || s.KeyGroup > keyRegexp.NumSubexp() {
+		return fmt.Errorf("key_group %d is out of range, key_pattern has %d capture groups", s.KeyGroup, keyRegexp.NumSubexp())
+	}
+	s.keyRegexp = keyRegexp
+
+	for field, names := range s.StepColumns {
+		if !contains(StepFields, field) {
+			return fmt.Errorf("step_columns: unknown step field %q", field)
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("step_columns: %s has no column names", field)
+		}
+	}
+
+	for outcome := range s.StatusOutcomes {
+		if _, known := defaultStatusOutcomes[outcome]; !known {
+			return fmt.Errorf("status_outcomes: unknown outcome %q", outcome)
+		}
+	}
+
+	return nil
+}
+
+// ExtractKey returns the test case key from a title, or "" when the title does not match
+func (s *Schema) ExtractKey(title string) string {
+	matches := s.keyRegexp.FindStringSubmatch(title)
+	if matches == nil {
+		return ""
+	}
+	return matches[s.KeyGroup]
+}
+
+// KeyRegexp returns the compiled key pattern
+func (s *Schema) KeyRegexp() *regexp.Regexp {
+	return s.keyRegexp
+}
+
+// ClassifyStatus maps a status name to one of the models.Outcome* values.
+// Configured outcomes take precedence over the default status names.
+func (s *Schema) ClassifyStatus(status string) string {
+	status = strings.TrimSpace(status)
+	for _, outcome := range outcomeOrder {
+		if containsFold(s.StatusOutcomes[outcome], status) {
+			return outcome
+		}
+	}
+	for _, outcome := range outcomeOrder {
+		if _, configured := s.StatusOutcomes[outcome]; configured {
+			continue
+		}
+		if containsFold(defaultStatusOutcomes[outcome], status) {
+			return outcome
+		}
+	}
+	return models.OutcomeNotRun
+}
+
+// StatusName returns the status name written for an outcome: the first
+// configured name, or the first default name
+func (s *Schema) StatusName(outcome string) string {
+	if names := s.StatusOutcomes[outcome]; len(names) > 0 {
+		return names[0]
+	}
+	if names := defaultStatusOutcomes[outcome]; len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return false
+}
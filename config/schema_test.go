@@ -0,0 +1,133 @@
+package config
+
+import (
+	"demo-notion-api/models"
+	"strings"
+	"testing"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *Schema)
+		wantErr string
+	}{
+		{name: "default schema", modify: func(s *Schema) {}},
+		{name: "whole match as key", modify: func(s *Schema) { s.KeyGroup = 0 }},
+		{name: "second capture group", modify: func(s *Schema) { s.KeyPattern, s.KeyGroup = `(TC|AUTH)-(\d+)`, 2 }},
+		{name: "configured step columns and outcomes", modify: func(s *Schema) {
+			s.StepColumns = map[string][]string{"action": {"Procedure"}}
+			s.StatusOutcomes = map[string][]string{models.OutcomePassed: {"OK"}}
+		}},
+		{name: "missing title property", modify: func(s *Schema) { s.TitleProperty = "" }, wantErr: "title_property is required"},
+		{name: "missing key pattern", modify: func(s *Schema) { s.KeyPattern = "" }, wantErr: "key_pattern is required"},
+		{name: "unknown status type", modify: func(s *Schema) { s.StatusType = "text" }, wantErr: `status_type must be status or select, got "text"`},
+		{name: "invalid key pattern", modify: func(s *Schema) { s.KeyPattern = `TC_(\d+` }, wantErr: "key_pattern: "},
+		{name: "negative key group", modify: func(s *Schema) { s.KeyGroup = -1 }, wantErr: "key_group -1 is out of range, key_pattern has 1 capture groups"},
+		{name: "key group after the last capture group", modify: func(s *Schema) { s.KeyGroup = 2 }, wantErr: "key_group 2 is out of range, key_pattern has 1 capture groups"},
+		{name: "key group without capture groups", modify: func(s *Schema) { s.KeyPattern = `TC_\d+` }, wantErr: "key_group 1 is out of range, key_pattern has 0 capture groups"},
+		{name: "unknown step field", modify: func(s *Schema) { s.StepColumns = map[string][]string{"notes": {"Notes"}} }, wantErr: `step_columns: unknown step field "notes"`},
+		{name: "step field without columns", modify: func(s *Schema) { s.StepColumns = map[string][]string{"action": {}} }, wantErr: "step_columns: action has no column names"},
+		{name: "unknown status outcome", modify: func(s *Schema) { s.StatusOutcomes = map[string][]string{"flaky": {"Flaky"}} }, wantErr: `status_outcomes: unknown outcome "flaky"`},
+		{name: "not run is not configurable", modify: func(s *Schema) { s.StatusOutcomes = map[string][]string{models.OutcomeNotRun: {"Todo"}} }, wantErr: `unknown outcome "not_run"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := DefaultSchema()
+			tt.modify(schema)
+
+			err := schema.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchemaExtractKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		group   int
+		title   string
+		want    string
+	}{
+		{name: "capture group", pattern: `TC_(\d+)`, group: 1, title: "TC_01001 Login", want: "01001"},
+		{name: "whole match", pattern: `TC_\d+`, group: 0, title: "Login TC_01001", want: "TC_01001"},
+		{name: "later capture group", pattern: `(TC|AUTH)-(\d+)`, group: 2, title: "AUTH-7 Logout", want: "7"},
+		{name: "no match", pattern: `TC_(\d+)`, group: 1, title: "Login", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := DefaultSchema()
+			schema.KeyPattern, schema.KeyGroup = tt.pattern, tt.group
+			if err := schema.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if got := schema.ExtractKey(tt.title); got != tt.want {
+				t.Errorf("ExtractKey(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaClassifyStatus(t *testing.T) {
+	configured := DefaultSchema()
+	configured.StatusOutcomes = map[string][]string{
+		models.OutcomePassed:  {"OK", "Verified"},
+		models.OutcomeSkipped: {"Done"},
+	}
+
+	tests := []struct {
+		name   string
+		schema *Schema
+		status string
+		want   string
+	}{
+		{name: "default passed", schema: DefaultSchema(), status: "Passed", want: models.OutcomePassed},
+		{name: "default names ignore case and spaces", schema: DefaultSchema(), status: "  n/a ", want: models.OutcomeSkipped},
+		{name: "default in progress", schema: DefaultSchema(), status: "In Progress", want: models.OutcomeInProgress},
+		{name: "unknown status", schema: DefaultSchema(), status: "Flaky", want: models.OutcomeNotRun},
+		{name: "empty status", schema: DefaultSchema(), status: "", want: models.OutcomeNotRun},
+		{name: "configured name", schema: configured, status: "verified", want: models.OutcomePassed},
+		{name: "configured names replace the defaults", schema: configured, status: "Passed", want: models.OutcomeNotRun},
+		{name: "configured names win over defaults of other outcomes", schema: configured, status: "Done", want: models.OutcomeSkipped},
+		{name: "outcomes that are not configured keep their defaults", schema: configured, status: "Failed", want: models.OutcomeFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schema.ClassifyStatus(tt.status); got != tt.want {
+				t.Errorf("ClassifyStatus(%q) = %q, want %q", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaStatusName(t *testing.T) {
+	schema := DefaultSchema()
+	schema.StatusOutcomes = map[string][]string{models.OutcomePassed: {"OK", "Verified"}}
+
+	tests := []struct {
+		outcome string
+		want    string
+	}{
+		{outcome: models.OutcomePassed, want: "OK"},
+		{outcome: models.OutcomeFailed, want: "Failed"},
+		{outcome: models.OutcomeNotRun, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := schema.StatusName(tt.outcome); got != tt.want {
+			t.Errorf("StatusName(%q) = %q, want %q", tt.outcome, got, tt.want)
+		}
+	}
+}
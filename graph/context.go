@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"context"
+	"demo-notion-api/models"
+	"demo-notion-api/services"
+	"sync"
+)
+
+type contextKey struct{}
+
+// requestLoaders holds the loaders of one GraphQL request, one set per project
+type requestLoaders struct {
+	projects *services.ProjectRegistry
+	// ctx is the request context, carried by every Notion call of the loaders
+	ctx context.Context
+
+	mu        sync.Mutex
+	byProject map[string]*projectLoaders
+}
+
+// projectLoaders batch and cache the Notion reads of one project
+type projectLoaders struct {
+	// testCases searches the project once per request; its only key is ""
+	testCases  *loader[string, []models.TestCaseResponse]
+	blocks     *loader[string, []models.BlockResponse]
+	tables     *loader[string, *models.TableWithData]
+	pageTables *loader[string, []models.TableWithData]
+}
+
+// NewContext returns a context carrying fresh loaders for one request
+func NewContext(ctx context.Context, projects *services.ProjectRegistry) context.Context {
+	return context.WithValue(ctx, contextKey{}, &requestLoaders{
+		projects:  projects,
+		ctx:       ctx,
+		byProject: make(map[string]*projectLoaders),
+	})
+}
+
+// loadersFor returns the loaders of a project, or of the default project when name is empty
+func loadersFor(ctx context.Context, name string) (*projectLoaders, error) {
+	request := ctx.Value(contextKey{}).(*requestLoaders)
+
+	project := request.projects.Default()
+	if name != "" {
+		var err error
+		if project, err = request.projects.Get(name); err != nil {
+			return nil, err
+		}
+	}
+
+	request.mu.Lock()
+	defer request.mu.Unlock()
+
+	if loaders, ok := request.byProject[project.Name]; ok {
+		return loaders, nil
+	}
+	loaders := newProjectLoaders(project.NotionService.WithContext(request.ctx))
+	request.byProject[project.Name] = loaders
+	return loaders, nil
+}
+
+func newProjectLoaders(notionService *services.NotionService) *projectLoaders {
+	l := &projectLoaders{}
+
+	l.testCases = newLoader("graphql_test_cases", func(keys []string) map[string]loaderResult[[]models.TestCaseResponse] {
+		return fetchConcurrently(keys, func(string) ([]models.TestCaseResponse, error) {
+			return notionService.SearchTestCases()
+		})
+	})
+
+	l.blocks = newLoader("graphql_blocks", func(pageIDs []string) map[string]loaderResult[[]models.BlockResponse] {
+		return fetchConcurrently(pageIDs, notionService.GetPageBlocks)
+	})
+
+	l.tables = newLoader("graphql_tables", func(blockIDs []string) map[string]loaderResult[*models.TableWithData] {
+		return fetchConcurrently(blockIDs, notionService.GetTableData)
+	})
+
+	// pageTables reads the blocks of every pending page in one batch, then the
+	// data of all their tables in a second one
+	l.pageTables = newLoader("graphql_page_tables", func(pageIDs []string) map[string]loaderResult[[]models.TableWithData] {
+		blocks := l.blocks.LoadMany(pageIDs)
+
+		var tableIDs []string
+		for _, pageID := range pageIDs {
+			for _, block := range blocks[pageID].value {
+				if block.Type == "table" {
+					tableIDs = append(tableIDs, block.BlockID)
+				}
+			}
+		}
+		tables := l.tables.LoadMany(tableIDs)
+
+		results := make(map[string]loaderResult[[]models.TableWithData], len(pageIDs))
+		for _, pageID := range pageIDs {
+			if err := blocks[pageID].err; err != nil {
+				results[pageID] = loaderResult[[]models.TableWithData]{err: err}
+				continue
+			}
+
+			pageTables := []models.TableWithData{}
+			var failed error
+			for _, block := range blocks[pageID].value {
+				if block.Type != "table" {
+					continue
+				}
+				table := tables[block.BlockID]
+				if table.err != nil {
+					failed = table.err
+					break
+				}
+				pageTables = append(pageTables, *table.value)
+			}
+			results[pageID] = loaderResult[[]models.TableWithData]{value: pageTables, err: failed}
+		}
+		return results
+	})
+
+	return l
+}
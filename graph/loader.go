@@ -0,0 +1,132 @@
+package graph
+
+import (
+	"demo-notion-api/metrics"
+	"sync"
+)
+
+// maxConcurrentFetches bounds the parallel Notion requests of one batch
+const maxConcurrentFetches = 4
+
+// loaderEntry is the result of one key, available once done is closed
+type loaderEntry[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// loaderResult is the value or error fetched for one key
+type loaderResult[V any] struct {
+	value V
+	err   error
+}
+
+// loader batches loads of one kind within a request. Resolvers call Load while
+// a level of the query is being resolved and get a thunk back; the executor
+// runs the thunks of a level only after all of its resolvers, so the first
+// thunk fetches every pending key in one batch. Keys are fetched once per request;
+// repeated loads are counted as hits of the named cache.
+type loader[K comparable, V any] struct {
+	name  string
+	batch func(keys []K) map[K]loaderResult[V]
+
+	mu      sync.Mutex
+	pending []K
+	entries map[K]*loaderEntry[V]
+}
+
+func newLoader[K comparable, V any](name string, batch func(keys []K) map[K]loaderResult[V]) *loader[K, V] {
+	return &loader[K, V]{
+		name:    name,
+		batch:   batch,
+		entries: make(map[K]*loaderEntry[V]),
+	}
+}
+
+// Load queues key for the next batch and returns a thunk resolving to its value
+func (l *loader[K, V]) Load(key K) func() (interface{}, error) {
+	entry := l.enqueue(key)
+	return func() (interface{}, error) {
+		l.dispatch()
+		<-entry.done
+		return entry.value, entry.err
+	}
+}
+
+// LoadMany fetches keys in one batch, together with any other pending keys
+func (l *loader[K, V]) LoadMany(keys []K) map[K]loaderResult[V] {
+	entries := make(map[K]*loaderEntry[V], len(keys))
+	for _, key := range keys {
+		entries[key] = l.enqueue(key)
+	}
+	l.dispatch()
+
+	results := make(map[K]loaderResult[V], len(keys))
+	for key, entry := range entries {
+		<-entry.done
+		results[key] = loaderResult[V]{value: entry.value, err: entry.err}
+	}
+	return results
+}
+
+func (l *loader[K, V]) enqueue(key K) *loaderEntry[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[key]
+	metrics.CacheLookup(l.name, ok)
+	if ok {
+		return entry
+	}
+	entry = &loaderEntry[V]{done: make(chan struct{})}
+	l.entries[key] = entry
+	l.pending = append(l.pending, key)
+	return entry
+}
+
+func (l *loader[K, V]) dispatch() {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	if len(keys) == 0 {
+		return
+	}
+
+	results := l.batch(keys)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		entry := l.entries[key]
+		result := results[key]
+		entry.value, entry.err = result.value, result.err
+		close(entry.done)
+	}
+}
+
+// fetchConcurrently runs fetch for every key with at most maxConcurrentFetches in flight
+func fetchConcurrently[K comparable, V any](keys []K, fetch func(K) (V, error)) map[K]loaderResult[V] {
+	results := make(map[K]loaderResult[V], len(keys))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxConcurrentFetches)
+
+	for _, key := range keys {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(key K) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			value, err := fetch(key)
+			mu.Lock()
+			results[key] = loaderResult[V]{value: value, err: err}
+			mu.Unlock()
+		}(key)
+	}
+
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,374 @@
+package graph
+
+import (
+	"demo-notion-api/models"
+	"demo-notion-api/services"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// blockSource is a block together with the project it was read from, so that
+// nested fields are read through the right Notion service
+type blockSource struct {
+	project string
+	block   models.BlockResponse
+}
+
+// testCaseConnection is one page of a test case listing
+type testCaseConnection struct {
+	nodes      []models.TestCaseResponse
+	pagination models.Pagination
+}
+
+// NewSchema builds the GraphQL schema. Nested fields are resolved lazily
+// through per-request loaders, so Notion is only read for requested fields;
+// requests must run with a context from NewContext. Test runs are the plans
+// of the default project's suites file.
+func NewSchema(suiteService *services.SuiteService) (graphql.Schema, error) {
+	stepType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Step",
+		Description: "A row of a step table",
+		Fields: graphql.Fields{
+			"blockId":        field(graphql.String, func(s models.TestStep) interface{} { return s.BlockID }),
+			"number":         field(graphql.Int, func(s models.TestStep) interface{} { return s.Number }),
+			"action":         field(graphql.String, func(s models.TestStep) interface{} { return s.Action }),
+			"expectedResult": field(graphql.String, func(s models.TestStep) interface{} { return s.ExpectedResult }),
+			"actualResult":   field(graphql.String, func(s models.TestStep) interface{} { return s.ActualResult }),
+			"status":         field(graphql.String, func(s models.TestStep) interface{} { return s.Status }),
+			"screenshot":     field(graphql.String, func(s models.TestStep) interface{} { return s.Screenshot }),
+		},
+	})
+
+	tableType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Table",
+		Description: "A table block with its rows",
+		Fields: graphql.Fields{
+			"blockId":         field(graphql.String, func(t models.TableWithData) interface{} { return t.BlockID }),
+			"width":           field(graphql.Int, func(t models.TableWithData) interface{} { return t.TableWidth }),
+			"hasColumnHeader": field(graphql.Boolean, func(t models.TableWithData) interface{} { return t.HasColumnHeader }),
+			"hasRowHeader":    field(graphql.Boolean, func(t models.TableWithData) interface{} { return t.HasRowHeader }),
+			"rows": field(graphql.NewList(graphql.NewList(graphql.String)), func(t models.TableWithData) interface{} {
+				rows := make([][]string, 0, len(t.Rows))
+				for _, row := range t.Rows {
+					rows = append(rows, row.Cells)
+				}
+				return rows
+			}),
+			"steps": field(graphql.NewList(stepType), func(t models.TableWithData) interface{} { return t.Steps }),
+		},
+	})
+
+	blockType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Block",
+		Description: "A top-level block of a test case page",
+		Fields: graphql.Fields{
+			"id":          field(graphql.String, func(b blockSource) interface{} { return b.block.BlockID }),
+			"type":        field(graphql.String, func(b blockSource) interface{} { return b.block.Type }),
+			"hasChildren": field(graphql.Boolean, func(b blockSource) interface{} { return b.block.HasChildren }),
+			"content":     field(graphql.String, func(b blockSource) interface{} { return b.block.Content }),
+			"table": &graphql.Field{
+				Type:        tableType,
+				Description: "Table data, for table blocks",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					source := p.Source.(blockSource)
+					if source.block.Type != "table" {
+						return nil, nil
+					}
+					loaders, err := loadersFor(p.Context, source.project)
+					if err != nil {
+						return nil, err
+					}
+					return then(loaders.tables.Load(source.block.BlockID), func(v interface{}) (interface{}, error) {
+						return *v.(*models.TableWithData), nil
+					}), nil
+				},
+			},
+		},
+	})
+
+	testCaseType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "TestCase",
+		Description: "A test case page",
+		Fields: graphql.Fields{
+			"project":      field(graphql.String, func(tc models.TestCaseResponse) interface{} { return tc.Project }),
+			"key":          field(graphql.String, func(tc models.TestCaseResponse) interface{} { return tc.TestCaseKey }),
+			"pageId":       field(graphql.String, func(tc models.TestCaseResponse) interface{} { return tc.PageID }),
+			"title":        field(graphql.String, func(tc models.TestCaseResponse) interface{} { return tc.Title }),
+			"status":       field(graphql.String, func(tc models.TestCaseResponse) interface{} { return tc.Status }),
+			"testDate":     field(graphql.String, func(tc models.TestCaseResponse) interface{} { return tc.TestDate }),
+			"tags":         field(graphql.NewList(graphql.String), func(tc models.TestCaseResponse) interface{} { return tc.Tags }),
+			"assignees":    field(graphql.NewList(graphql.String), func(tc models.TestCaseResponse) interface{} { return tc.Assignees }),
+			"url":          field(graphql.String, func(tc models.TestCaseResponse) interface{} { return tc.URL }),
+			"lastEdited":   field(graphql.String, func(tc models.TestCaseResponse) interface{} { return tc.LastEdited.Format(time.RFC3339) }),
+			"lastEditedBy": field(graphql.String, func(tc models.TestCaseResponse) interface{} { return tc.LastEditedBy }),
+			"blocks": &graphql.Field{
+				Type: graphql.NewList(blockType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					tc := p.Source.(models.TestCaseResponse)
+					loaders, err := loadersFor(p.Context, tc.Project)
+					if err != nil {
+						return nil, err
+					}
+					return then(loaders.blocks.Load(tc.PageID), func(v interface{}) (interface{}, error) {
+						var blocks []blockSource
+						for _, block := range v.([]models.BlockResponse) {
+							blocks = append(blocks, blockSource{project: tc.Project, block: block})
+						}
+						return blocks, nil
+					}), nil
+				},
+			},
+			"tables": &graphql.Field{
+				Type: graphql.NewList(tableType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					tc := p.Source.(models.TestCaseResponse)
+					loaders, err := loadersFor(p.Context, tc.Project)
+					if err != nil {
+						return nil, err
+					}
+					return loaders.pageTables.Load(tc.PageID), nil
+				},
+			},
+			"steps": &graphql.Field{
+				Type:        graphql.NewList(stepType),
+				Description: "Steps of every step table of the page",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					tc := p.Source.(models.TestCaseResponse)
+					loaders, err := loadersFor(p.Context, tc.Project)
+					if err != nil {
+						return nil, err
+					}
+					return then(loaders.pageTables.Load(tc.PageID), func(v interface{}) (interface{}, error) {
+						var steps []models.TestStep
+						for _, table := range v.([]models.TableWithData) {
+							steps = append(steps, table.Steps...)
+						}
+						return steps, nil
+					}), nil
+				},
+			},
+		},
+	})
+
+	progressType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Progress",
+		Fields: graphql.Fields{
+			"total":      field(graphql.Int, func(p models.ProgressSummary) interface{} { return p.Total }),
+			"executed":   field(graphql.Int, func(p models.ProgressSummary) interface{} { return p.Executed }),
+			"remaining":  field(graphql.Int, func(p models.ProgressSummary) interface{} { return p.Remaining }),
+			"passed":     field(graphql.Int, func(p models.ProgressSummary) interface{} { return p.Passed }),
+			"failed":     field(graphql.Int, func(p models.ProgressSummary) interface{} { return p.Failed }),
+			"blocked":    field(graphql.Int, func(p models.ProgressSummary) interface{} { return p.Blocked }),
+			"skipped":    field(graphql.Int, func(p models.ProgressSummary) interface{} { return p.Skipped }),
+			"inProgress": field(graphql.Int, func(p models.ProgressSummary) interface{} { return p.InProgress }),
+			"notRun":     field(graphql.Int, func(p models.ProgressSummary) interface{} { return p.NotRun }),
+			"passRate":   field(graphql.Float, func(p models.ProgressSummary) interface{} { return p.PassRate }),
+		},
+	})
+
+	// planTestCases resolves the members of a plan from the request's search of the default project
+	planTestCases := func(p graphql.ResolveParams) (func() (interface{}, error), error) {
+		plan := p.Source.(models.TestPlan)
+		loaders, err := loadersFor(p.Context, "")
+		if err != nil {
+			return nil, err
+		}
+		return then(loaders.testCases.Load(""), func(v interface{}) (interface{}, error) {
+			return suiteService.PlanMembers(plan.Name, v.([]models.TestCaseResponse))
+		}), nil
+	}
+
+	testRunType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "TestRun",
+		Description: "A test plan and the test cases of its suites",
+		Fields: graphql.Fields{
+			"name":        field(graphql.String, func(p models.TestPlan) interface{} { return p.Name }),
+			"release":     field(graphql.String, func(p models.TestPlan) interface{} { return p.Release }),
+			"description": field(graphql.String, func(p models.TestPlan) interface{} { return p.Description }),
+			"suites":      field(graphql.NewList(graphql.String), func(p models.TestPlan) interface{} { return p.Suites }),
+			"testCases": &graphql.Field{
+				Type: graphql.NewList(testCaseType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					members, err := planTestCases(p)
+					if err != nil {
+						return nil, err
+					}
+					return members, nil
+				},
+			},
+			"progress": &graphql.Field{
+				Type: progressType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					members, err := planTestCases(p)
+					if err != nil {
+						return nil, err
+					}
+					return then(members, func(v interface{}) (interface{}, error) {
+						schema := suiteService.NotionService().Schema()
+						return services.SummarizeProgress(schema, v.([]models.TestCaseResponse)), nil
+					}), nil
+				},
+			},
+		},
+	})
+
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TestCaseConnection",
+		Fields: graphql.Fields{
+			"nodes":      field(graphql.NewList(testCaseType), func(c testCaseConnection) interface{} { return c.nodes }),
+			"totalCount": field(graphql.Int, func(c testCaseConnection) interface{} { return c.pagination.Total }),
+			"hasMore":    field(graphql.Boolean, func(c testCaseConnection) interface{} { return c.pagination.HasMore }),
+			"nextCursor": field(graphql.String, func(c testCaseConnection) interface{} { return c.pagination.NextCursor }),
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"testCases": &graphql.Field{
+				Type:        connectionType,
+				Description: "Test cases with the filters, sort and pagination of GET /api/test-cases",
+				Args: graphql.FieldConfigArgument{
+					"project":      {Type: graphql.String},
+					"status":       {Type: graphql.NewList(graphql.String)},
+					"testDateFrom": {Type: graphql.String},
+					"testDateTo":   {Type: graphql.String},
+					"keyPrefix":    {Type: graphql.String},
+					"keyFrom":      {Type: graphql.String},
+					"keyTo":        {Type: graphql.String},
+					"title":        {Type: graphql.String},
+					"tag":          {Type: graphql.NewList(graphql.String)},
+					"assignee":     {Type: graphql.String},
+					"sort":         {Type: graphql.String},
+					"descending":   {Type: graphql.Boolean},
+					"limit":        {Type: graphql.Int},
+					"cursor":       {Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					query := testCaseQuery(p.Args)
+					if err := services.ValidateQuery(query); err != nil {
+						return nil, err
+					}
+
+					project, _ := p.Args["project"].(string)
+					loaders, err := loadersFor(p.Context, project)
+					if err != nil {
+						return nil, err
+					}
+					return then(loaders.testCases.Load(""), func(v interface{}) (interface{}, error) {
+						nodes, pagination, err := services.ApplyQuery(v.([]models.TestCaseResponse), query)
+						if err != nil {
+							return nil, err
+						}
+						return testCaseConnection{nodes: nodes, pagination: pagination}, nil
+					}), nil
+				},
+			},
+			"testCase": &graphql.Field{
+				Type: testCaseType,
+				Args: graphql.FieldConfigArgument{
+					"key":     {Type: graphql.NewNonNull(graphql.String)},
+					"project": {Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					key := p.Args["key"].(string)
+					project, _ := p.Args["project"].(string)
+					loaders, err := loadersFor(p.Context, project)
+					if err != nil {
+						return nil, err
+					}
+					return then(loaders.testCases.Load(""), func(v interface{}) (interface{}, error) {
+						for _, tc := range v.([]models.TestCaseResponse) {
+							if tc.TestCaseKey == key {
+								return tc, nil
+							}
+						}
+						return nil, nil
+					}), nil
+				},
+			},
+			"testRuns": &graphql.Field{
+				Type: graphql.NewList(testRunType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return suiteService.ListPlans(), nil
+				},
+			},
+			"testRun": &graphql.Field{
+				Type: testRunType,
+				Args: graphql.FieldConfigArgument{
+					"name": {Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					name := p.Args["name"].(string)
+					for _, plan := range suiteService.ListPlans() {
+						if plan.Name == name {
+							return plan, nil
+						}
+					}
+					return nil, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// Helper functions
+
+// field resolves a field from a typed source value
+func field[S any](t graphql.Output, get func(S) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(S)), nil
+		},
+	}
+}
+
+// then maps the value of a thunk without running it
+func then(thunk func() (interface{}, error), fn func(interface{}) (interface{}, error)) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		value, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		return fn(value)
+	}
+}
+
+func testCaseQuery(args map[string]interface{}) models.TestCaseQuery {
+	str := func(name string) string {
+		value, _ := args[name].(string)
+		return value
+	}
+	list := func(name string) []string {
+		var values []string
+		items, _ := args[name].([]interface{})
+		for _, item := range items {
+			if value, ok := item.(string); ok {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+
+	limit, _ := args["limit"].(int)
+	descending, _ := args["descending"].(bool)
+	return models.TestCaseQuery{
+		Statuses:     list("status"),
+		TestDateFrom: str("testDateFrom"),
+		TestDateTo:   str("testDateTo"),
+		KeyPrefix:    str("keyPrefix"),
+		KeyFrom:      str("keyFrom"),
+		KeyTo:        str("keyTo"),
+		Title:        str("title"),
+		Tags:         list("tag"),
+		Assignee:     str("assignee"),
+		Sort:         str("sort"),
+		Descending:   descending,
+		Limit:        limit,
+		Cursor:       str("cursor"),
+	}
+}
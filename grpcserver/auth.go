@@ -0,0 +1,77 @@
+package grpcserver
+
+import (
+	"context"
+	"demo-notion-api/auth"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryAuthInterceptor authenticates unary calls like the HTTP auth middleware
+func UnaryAuthInterceptor(authenticator auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor authenticates streaming calls like the HTTP auth middleware
+func StreamAuthInterceptor(authenticator auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &identityStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// identityStream carries the authenticated context into stream handlers
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
+
+// Helper functions
+
+// authenticate reads the credential from the authorization or x-api-key metadata
+func authenticate(ctx context.Context, authenticator auth.Authenticator, method string) (context.Context, error) {
+	// Server reflection stays public, like /api/health
+	if strings.HasPrefix(method, "/grpc.reflection.") {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	credential := auth.Credential(first(md, "authorization"), first(md, strings.ToLower(auth.APIKeyHeader)))
+
+	identity, err := authenticator.Authenticate(credential)
+	if err != nil {
+		slog.WarnContext(ctx, "authentication failed", slog.String("grpc_method", method), slog.Any("error", err))
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	slog.InfoContext(ctx, "authenticated",
+		slog.String("subject", identity.Subject),
+		slog.String("method", identity.Method),
+	)
+	return auth.WithIdentity(ctx, identity), nil
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
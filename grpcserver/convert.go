@@ -0,0 +1,147 @@
+package grpcserver
+
+import (
+	"demo-notion-api/models"
+	"demo-notion-api/proto/notionpb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toTestCase(tc models.TestCaseResponse) *notionpb.TestCase {
+	message := &notionpb.TestCase{
+		Project:      tc.Project,
+		TestCaseKey:  tc.TestCaseKey,
+		PageId:       tc.PageID,
+		Title:        tc.Title,
+		Status:       tc.Status,
+		TestDate:     tc.TestDate,
+		Tags:         tc.Tags,
+		Assignees:    tc.Assignees,
+		Url:          tc.URL,
+		LastEditedBy: tc.LastEditedBy,
+	}
+	if !tc.LastEdited.IsZero() {
+		message.LastEdited = timestamppb.New(tc.LastEdited)
+	}
+	return message
+}
+
+func toDetailedTestCase(tc models.DetailedTestCaseResponse) *notionpb.DetailedTestCase {
+	message := &notionpb.DetailedTestCase{
+		TestCase: toTestCase(models.TestCaseResponse{
+			Project:      tc.Project,
+			TestCaseKey:  tc.TestCaseKey,
+			PageID:       tc.PageID,
+			Title:        tc.Title,
+			Status:       tc.Status,
+			TestDate:     tc.TestDate,
+			Tags:         tc.Tags,
+			Assignees:    tc.Assignees,
+			URL:          tc.URL,
+			LastEdited:   tc.LastEdited,
+			LastEditedBy: tc.LastEditedBy,
+		}),
+	}
+	for _, table := range tc.Tables {
+		message.Tables = append(message.Tables, toTable(table))
+	}
+	for _, e := range tc.Errors {
+		message.Errors = append(message.Errors, &notionpb.TestCaseError{
+			TestCaseKey: e.TestCaseKey,
+			PageId:      e.PageID,
+			BlockId:     e.BlockID,
+			Message:     e.Message,
+		})
+	}
+	return message
+}
+
+func toTable(table models.TableWithData) *notionpb.TableWithData {
+	message := &notionpb.TableWithData{
+		BlockId:         table.BlockID,
+		TableWidth:      int32(table.TableWidth),
+		HasColumnHeader: table.HasColumnHeader,
+		HasRowHeader:    table.HasRowHeader,
+		Rows:            toRows(table.Rows),
+	}
+	for _, step := range table.Steps {
+		message.Steps = append(message.Steps, toStep(step))
+	}
+	for _, warning := range table.Warnings {
+		message.Warnings = append(message.Warnings, &notionpb.StepWarning{Row: int32(warning.Row), Message: warning.Message})
+	}
+	return message
+}
+
+func toRows(rows []models.TableRow) []*notionpb.TableRow {
+	var messages []*notionpb.TableRow
+	for _, row := range rows {
+		messages = append(messages, &notionpb.TableRow{BlockId: row.BlockID, Cells: row.Cells})
+	}
+	return messages
+}
+
+func toStep(step models.TestStep) *notionpb.TestStep {
+	return &notionpb.TestStep{
+		BlockId:        step.BlockID,
+		Number:         int32(step.Number),
+		Action:         step.Action,
+		ExpectedResult: step.ExpectedResult,
+		ActualResult:   step.ActualResult,
+		Status:         step.Status,
+		Screenshot:     step.Screenshot,
+		Extra:          step.Extra,
+	}
+}
+
+func fromStep(step *notionpb.TestStep) models.TestStep {
+	return models.TestStep{
+		BlockID:        step.GetBlockId(),
+		Number:         int(step.GetNumber()),
+		Action:         step.GetAction(),
+		ExpectedResult: step.GetExpectedResult(),
+		ActualResult:   step.GetActualResult(),
+		Status:         step.GetStatus(),
+		Screenshot:     step.GetScreenshot(),
+		Extra:          step.GetExtra(),
+	}
+}
+
+func toBlock(block models.BlockResponse) *notionpb.Block {
+	message := &notionpb.Block{
+		BlockId:     block.BlockID,
+		Type:        block.Type,
+		HasChildren: block.HasChildren,
+		Content:     block.Content,
+	}
+	if block.TableInfo != nil {
+		message.TableInfo = &notionpb.TableInfo{
+			TableWidth:      int32(block.TableInfo.TableWidth),
+			HasColumnHeader: block.TableInfo.HasColumnHeader,
+			HasRowHeader:    block.TableInfo.HasRowHeader,
+			Rows:            toRows(block.TableInfo.Rows),
+		}
+	}
+	for _, child := range block.Children {
+		message.Children = append(message.Children, toBlock(child))
+	}
+	return message
+}
+
+func fromListRequest(req *notionpb.ListTestCasesRequest) models.TestCaseQuery {
+	return models.TestCaseQuery{
+		Statuses:     req.GetStatuses(),
+		TestDateFrom: req.GetTestDateFrom(),
+		TestDateTo:   req.GetTestDateTo(),
+		KeyPrefix:    req.GetKeyPrefix(),
+		KeyFrom:      req.GetKeyFrom(),
+		KeyTo:        req.GetKeyTo(),
+		Title:        req.GetTitle(),
+		Tags:         req.GetTags(),
+		Assignee:     req.GetAssignee(),
+		Sort:         req.GetSort(),
+		Descending:   req.GetDescending(),
+		Limit:        int(req.GetLimit()),
+		Cursor:       req.GetCursor(),
+	}
+}
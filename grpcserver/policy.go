@@ -0,0 +1,105 @@
+package grpcserver
+
+import (
+	"context"
+	"demo-notion-api/auth"
+	"demo-notion-api/proto/notionpb"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// methodRoles is the role each RPC requires in the project of its request
+var methodRoles = map[string]string{
+	notionpb.TestCaseService_ListTestCases_FullMethodName:           auth.RoleViewer,
+	notionpb.TestCaseService_StreamDetailedTestCases_FullMethodName: auth.RoleViewer,
+	notionpb.TestCaseService_GetTestCase_FullMethodName:             auth.RoleViewer,
+	notionpb.TestCaseService_GetTestCaseBlocks_FullMethodName:       auth.RoleViewer,
+	notionpb.TestCaseService_GetBlock_FullMethodName:                auth.RoleViewer,
+	notionpb.TestCaseService_GetTable_FullMethodName:                auth.RoleViewer,
+	notionpb.TestCaseService_UpdateTestCase_FullMethodName:          auth.RoleTester,
+	notionpb.TestCaseService_UpdateTableRow_FullMethodName:          auth.RoleTester,
+	notionpb.TestCaseService_CreateTestCase_FullMethodName:          auth.RoleLead,
+}
+
+// projectRequest is implemented by every request message of the service
+type projectRequest interface {
+	GetProject() string
+}
+
+// UnaryPolicyInterceptor checks the role of the caller like the HTTP route groups.
+// It must run after UnaryAuthInterceptor.
+func UnaryPolicyInterceptor(policy *auth.Policy, defaultProject string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authorize(ctx, policy, defaultProject, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamPolicyInterceptor checks the role of the caller once the request of a
+// server stream has been received. It must run after StreamAuthInterceptor.
+func StreamPolicyInterceptor(policy *auth.Policy, defaultProject string) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &policyStream{
+			ServerStream: stream,
+			authorize: func(req any) error {
+				return authorize(stream.Context(), policy, defaultProject, info.FullMethod, req)
+			},
+		})
+	}
+}
+
+// policyStream authorizes every received request message before the handler sees it
+type policyStream struct {
+	grpc.ServerStream
+	authorize func(req any) error
+}
+
+func (s *policyStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.authorize(m)
+}
+
+// Helper functions
+
+// authorize requires the role of method in the project of req. Unknown
+// methods require the lead role; server reflection stays public.
+func authorize(ctx context.Context, policy *auth.Policy, defaultProject, method string, req any) error {
+	if policy == nil || strings.HasPrefix(method, "/grpc.reflection.") {
+		return nil
+	}
+
+	identity, ok := auth.IdentityFrom(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "a valid API key or bearer token is required")
+	}
+
+	role, ok := methodRoles[method]
+	if !ok {
+		role = auth.RoleLead
+	}
+
+	project := defaultProject
+	if r, ok := req.(projectRequest); ok && r.GetProject() != "" {
+		project = r.GetProject()
+	}
+
+	if !policy.Allows(identity.Subject, role, project) {
+		slog.WarnContext(ctx, "access denied",
+			slog.String("subject", identity.Subject),
+			slog.String("role", role),
+			slog.String("scope", "project "+project),
+			slog.String("grpc_method", method),
+		)
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("%s role required in project %s", role, project))
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package grpcserver
+
+import (
+	"context"
+	"demo-notion-api/auth"
+	"demo-notion-api/proto/notionpb"
+	"demo-notion-api/ratelimit"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// expensiveMethods read every page and table of a project, like the expensive HTTP routes
+var expensiveMethods = map[string]bool{
+	notionpb.TestCaseService_StreamDetailedTestCases_FullMethodName: true,
+}
+
+// UnaryRateLimitInterceptor applies the HTTP rate limits to unary calls.
+// It must run after UnaryAuthInterceptor so that callers are keyed by identity.
+func UnaryRateLimitInterceptor(limiter, expensive *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := limit(ctx, limiter, expensive, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimitInterceptor applies the HTTP rate limits to streaming calls
+func StreamRateLimitInterceptor(limiter, expensive *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := limit(stream.Context(), limiter, expensive, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// Helper functions
+
+// limit takes a token from each limiter that applies to method. The limits are
+// reported in x-ratelimit header metadata and RESOURCE_EXHAUSTED is returned
+// with a retry-after header when a bucket is empty.
+func limit(ctx context.Context, limiter, expensive *ratelimit.Limiter, method string) error {
+	limiters := []*ratelimit.Limiter{limiter}
+	if expensiveMethods[method] {
+		limiters = append(limiters, expensive)
+	}
+
+	key := callerKey(ctx)
+	var header metadata.MD
+	for _, l := range limiters {
+		if l == nil {
+			continue
+		}
+
+		// The headers report the last, most specific limiter that applies
+		result := l.Allow(key)
+		header = metadata.Pairs(
+			"x-ratelimit-limit", strconv.Itoa(result.Limit),
+			"x-ratelimit-remaining", strconv.Itoa(result.Remaining),
+			"x-ratelimit-reset", strconv.Itoa(ceilSeconds(result.Reset.Seconds())),
+		)
+		if !result.Allowed {
+			retryAfter := ceilSeconds(result.RetryAfter.Seconds())
+			header.Append("retry-after", strconv.Itoa(retryAfter))
+			grpc.SetHeader(ctx, header)
+			slog.WarnContext(ctx, "rate limited",
+				slog.String("key", key),
+				slog.String("limit", l.Name()),
+				slog.String("grpc_method", method),
+			)
+			return status.Error(codes.ResourceExhausted, fmt.Sprintf("%s rate limit exceeded, retry in %d seconds", l.Name(), retryAfter))
+		}
+	}
+
+	if header != nil {
+		grpc.SetHeader(ctx, header)
+	}
+	return nil
+}
+
+// callerKey keys callers like ratelimit.Key: by identity, else by peer IP
+func callerKey(ctx context.Context) string {
+	if identity, ok := auth.IdentityFrom(ctx); ok {
+		return identity.Method + ":" + identity.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return "ip:" + host
+		}
+		return "ip:" + p.Addr.String()
+	}
+	return "ip:unknown"
+}
+
+func ceilSeconds(seconds float64) int {
+	return int(math.Ceil(seconds))
+}
@@ -0,0 +1,277 @@
+package grpcserver
+
+import (
+	"context"
+	"demo-notion-api/models"
+	"demo-notion-api/proto/notionpb"
+	"demo-notion-api/services"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Server implements notionpb.TestCaseServiceServer on top of the project registry
+type Server struct {
+	notionpb.UnimplementedTestCaseServiceServer
+	projects *services.ProjectRegistry
+}
+
+func NewServer(projects *services.ProjectRegistry) *Server {
+	return &Server{
+		projects: projects,
+	}
+}
+
+func (s *Server) ListTestCases(ctx context.Context, req *notionpb.ListTestCasesRequest) (*notionpb.ListTestCasesResponse, error) {
+	notionService, err := s.notionService(ctx, req.GetProject())
+	if err != nil {
+		return nil, err
+	}
+
+	testCases, pagination, err := notionService.QueryTestCases(fromListRequest(req))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	response := &notionpb.ListTestCasesResponse{
+		Total:      int32(pagination.Total),
+		HasMore:    pagination.HasMore,
+		NextCursor: pagination.NextCursor,
+	}
+	for _, tc := range testCases {
+		response.TestCases = append(response.TestCases, toTestCase(tc))
+	}
+	return response, nil
+}
+
+func (s *Server) StreamDetailedTestCases(req *notionpb.ListTestCasesRequest, stream notionpb.TestCaseService_StreamDetailedTestCasesServer) error {
+	notionService, err := s.notionService(stream.Context(), req.GetProject())
+	if err != nil {
+		return err
+	}
+
+	_, err = notionService.StreamDetailedTestCases(fromListRequest(req), func(tc models.DetailedTestCaseResponse, _ models.StreamProgress) error {
+		if err := stream.Context().Err(); err != nil {
+			return err
+		}
+		return stream.Send(toDetailedTestCase(tc))
+	})
+	return grpcError(err)
+}
+
+func (s *Server) GetTestCase(ctx context.Context, req *notionpb.GetTestCaseRequest) (*notionpb.TestCase, error) {
+	notionService, err := s.notionService(ctx, req.GetProject())
+	if err != nil {
+		return nil, err
+	}
+
+	testCase, err := notionService.GetTestCaseByKey(req.GetTestCaseKey())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toTestCase(*testCase), nil
+}
+
+func (s *Server) GetTestCaseBlocks(ctx context.Context, req *notionpb.GetTestCaseBlocksRequest) (*notionpb.GetTestCaseBlocksResponse, error) {
+	notionService, err := s.notionService(ctx, req.GetProject())
+	if err != nil {
+		return nil, err
+	}
+
+	testCase, err := notionService.GetTestCaseByKey(req.GetTestCaseKey())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	var blocks []models.BlockResponse
+	if req.GetTablesOnly() {
+		blocks, err = notionService.GetTableBlocks(testCase.PageID)
+	} else {
+		blocks, err = notionService.GetPageBlocks(testCase.PageID)
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	response := &notionpb.GetTestCaseBlocksResponse{TestCase: toTestCase(*testCase)}
+	for _, block := range blocks {
+		response.Blocks = append(response.Blocks, toBlock(block))
+	}
+	return response, nil
+}
+
+func (s *Server) GetBlock(ctx context.Context, req *notionpb.GetBlockRequest) (*notionpb.Block, error) {
+	notionService, err := s.notionService(ctx, req.GetProject())
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := notionService.GetBlockDetails(req.GetBlockId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toBlock(*block), nil
+}
+
+func (s *Server) GetTable(ctx context.Context, req *notionpb.GetBlockRequest) (*notionpb.TableWithData, error) {
+	notionService, err := s.notionService(ctx, req.GetProject())
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := notionService.GetTableData(req.GetBlockId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return toTable(*table), nil
+}
+
+func (s *Server) UpdateTestCase(ctx context.Context, req *notionpb.UpdateTestCaseRequest) (*notionpb.TestCase, error) {
+	notionService, err := s.notionService(ctx, req.GetProject())
+	if err != nil {
+		return nil, err
+	}
+
+	testCase, err := notionService.GetTestCaseByKey(req.GetTestCaseKey())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	update := models.TestCaseUpdate{
+		Title:    req.Title,
+		Status:   req.Status,
+		TestDate: req.TestDate,
+	}
+	if req.GetTags() != nil {
+		tags := req.GetTags().GetValues()
+		update.Tags = &tags
+	}
+
+	if err := notionService.UpdateTestCase(testCase.PageID, update); err != nil {
+		return nil, grpcError(err)
+	}
+
+	if update.Title != nil {
+		testCase.Title = *update.Title
+	}
+	if update.Status != nil {
+		testCase.Status = *update.Status
+	}
+	if update.TestDate != nil {
+		testCase.TestDate = *update.TestDate
+	}
+	if update.Tags != nil {
+		testCase.Tags = *update.Tags
+	}
+	return toTestCase(*testCase), nil
+}
+
+func (s *Server) CreateTestCase(ctx context.Context, req *notionpb.CreateTestCaseRequest) (*notionpb.TestCase, error) {
+	notionService, err := s.notionService(ctx, req.GetProject())
+	if err != nil {
+		return nil, err
+	}
+
+	key := notionService.Schema().ExtractKey(req.GetTitle())
+	if key == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "title %q does not contain a test case key", req.GetTitle())
+	}
+	if _, err := notionService.GetTestCaseByKey(key); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "test case %s already exists", key)
+	} else if !errors.Is(err, services.ErrTestCaseNotFound) {
+		return nil, grpcError(err)
+	}
+
+	title, testStatus, testDate, tags := req.GetTitle(), req.GetStatus(), req.GetTestDate(), req.GetTags()
+	update := models.TestCaseUpdate{Title: &title}
+	if testStatus != "" {
+		update.Status = &testStatus
+	}
+	if testDate != "" {
+		update.TestDate = &testDate
+	}
+	if len(tags) > 0 {
+		update.Tags = &tags
+	}
+
+	var steps []models.TestStep
+	for i, step := range req.GetSteps() {
+		converted := fromStep(step)
+		if converted.Number == 0 {
+			converted.Number = i + 1
+		}
+		steps = append(steps, converted)
+	}
+
+	page, err := notionService.CreateTestCase(update, steps)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return toTestCase(models.TestCaseResponse{
+		Project:     req.GetProject(),
+		TestCaseKey: key,
+		PageID:      page.ID,
+		Title:       title,
+		Status:      testStatus,
+		TestDate:    testDate,
+		Tags:        tags,
+		URL:         page.URL,
+		LastEdited:  page.LastEditedTime,
+	}), nil
+}
+
+func (s *Server) UpdateTableRow(ctx context.Context, req *notionpb.UpdateTableRowRequest) (*emptypb.Empty, error) {
+	notionService, err := s.notionService(ctx, req.GetProject())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := notionService.UpdateTableRow(req.GetBlockId(), req.GetCells()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Helper methods
+
+// notionService resolves a project, or the default project when name is empty,
+// bound to the context of the call
+func (s *Server) notionService(ctx context.Context, name string) (*services.NotionService, error) {
+	if name == "" {
+		return s.projects.Default().NotionService.WithContext(ctx), nil
+	}
+
+	project, err := s.projects.Get(name)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return project.NotionService.WithContext(ctx), nil
+}
+
+// Helper functions
+
+// grpcError maps service errors to gRPC status codes. Only Notion rate limits
+// and server errors are reported as retryable; anything unclassified is Internal.
+func grpcError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, services.ErrProjectNotFound), errors.Is(err, services.ErrTestCaseNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidQuery):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, services.ErrNotionRateLimited):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, services.ErrNotionUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,39 @@
+package grpcserver
+
+import (
+	"context"
+	"demo-notion-api/services"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{name: "unknown project", err: fmt.Errorf("%w: mobile", services.ErrProjectNotFound), want: codes.NotFound},
+		{name: "unknown test case", err: fmt.Errorf("%w: 01001", services.ErrTestCaseNotFound), want: codes.NotFound},
+		{name: "invalid query", err: fmt.Errorf("%w: unknown sort field", services.ErrInvalidQuery), want: codes.InvalidArgument},
+		{name: "canceled", err: fmt.Errorf("failed to execute request: %w", context.Canceled), want: codes.Canceled},
+		{name: "deadline", err: fmt.Errorf("failed to execute request: %w", context.DeadlineExceeded), want: codes.DeadlineExceeded},
+		{name: "notion rate limit", err: fmt.Errorf("failed to search: %w", services.ErrNotionRateLimited), want: codes.ResourceExhausted},
+		{name: "notion server error", err: fmt.Errorf("failed to search: %w", services.ErrNotionUnavailable), want: codes.Unavailable},
+		{name: "notion validation error", err: errors.New("notion API error: status 400, body: {}"), want: codes.Internal},
+		{name: "local failure", err: errors.New("failed to decode response"), want: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		if got := status.Code(grpcError(tt.err)); got != tt.want {
+			t.Errorf("%s: grpcError() code = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if grpcError(nil) != nil {
+		t.Error("grpcError(nil) != nil")
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"demo-notion-api/services"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+type ExportHandler struct {
+	exportService *services.ExportService
+}
+
+func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+// ExportTestCases godoc
+// @Summary Export test cases as a spreadsheet
+// @Description Export detailed test cases as CSV or XLSX with one row per step. XLSX has one sheet per suite.
+// @Tags testcases
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Export format (csv or xlsx)" default(csv)
+// @Param strict query bool false "Fail when any table cannot be read" default(false)
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/test-cases/export [get]
+func (h *ExportHandler) ExportTestCases(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+	filename := fmt.Sprintf("test-cases-%s.%s", time.Now().Format("20060102"), format)
+
+	switch format {
+	case "csv":
+		rows, testCases, err := h.exportService.WithContext(c.Request.Context()).ExportRows()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "Failed to export test cases",
+				Message: err.Error(),
+			})
+			return
+		}
+		if !checkIncomplete(c, "Failed to export test cases", services.CheckComplete(testCases)) {
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(http.StatusOK)
+		if err := services.WriteCSV(c.Writer, rows); err != nil {
+			c.Error(err)
+		}
+
+	case "xlsx":
+		sheets, testCases, err := h.exportService.WithContext(c.Request.Context()).ExportSheets()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "Failed to export test cases",
+				Message: err.Error(),
+			})
+			return
+		}
+		if !checkIncomplete(c, "Failed to export test cases", services.CheckComplete(testCases)) {
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Header("Content-Type", xlsxContentType)
+		c.Status(http.StatusOK)
+		if err := services.WriteXLSX(c.Writer, sheets); err != nil {
+			c.Error(err)
+		}
+
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid format",
+			Message: "format must be csv or xlsx",
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"demo-notion-api/models"
+	"demo-notion-api/services"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const featureContentType = "text/x-gherkin; charset=utf-8"
+
+type GherkinHandler struct {
+	notionService *services.NotionService
+	importService *services.ImportService
+}
+
+func NewGherkinHandler(notionService *services.NotionService, importService *services.ImportService) *GherkinHandler {
+	return &GherkinHandler{
+		notionService: notionService,
+		importService: importService,
+	}
+}
+
+// GetFeature godoc
+// @Summary Export test cases as a Gherkin feature file
+// @Description Render every test case as a scenario tagged with its key
+// @Tags gherkin
+// @Produce plain
+// @Param strict query bool false "Fail when any table cannot be read" default(false)
+// @Success 200 {string} string
+// @Failure 500 {object} ErrorResponse
+// @Router /api/test-cases/feature [get]
+func (h *GherkinHandler) GetFeature(c *gin.Context) {
+	notionService := h.notionService.WithContext(c.Request.Context())
+	detailed, err := notionService.GetDetailedTestCases()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get detailed test cases",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if !checkIncomplete(c, "Failed to get detailed test cases", services.CheckComplete(detailed)) {
+		return
+	}
+
+	feature := services.RenderFeature("Test cases", detailed, notionService.Schema())
+	c.Header("Content-Disposition", `attachment; filename="test-cases.feature"`)
+	c.Data(http.StatusOK, featureContentType, []byte(feature))
+}
+
+// GetTestCaseFeature godoc
+// @Summary Export a test case as a Gherkin feature file
+// @Description Render one test case as a feature with a single scenario
+// @Tags gherkin
+// @Produce plain
+// @Param testCaseKey path string true "Test Case Key (e.g., 01001)"
+// @Param strict query bool false "Fail when any table cannot be read" default(false)
+// @Success 200 {string} string
+// @Failure 404 {object} ErrorResponse
+// @Router /api/test-cases/{testCaseKey}/feature [get]
+func (h *GherkinHandler) GetTestCaseFeature(c *gin.Context) {
+	notionService := h.notionService.WithContext(c.Request.Context())
+	testCase, err := notionService.GetTestCaseByKey(c.Param("testCaseKey"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Test case not found",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	detailed := notionService.GetDetailedTestCase(*testCase)
+	if !checkIncomplete(c, "Failed to get detailed test case", services.CheckComplete([]models.DetailedTestCaseResponse{detailed})) {
+		return
+	}
+
+	feature := services.RenderFeature(testCase.Title, []models.DetailedTestCaseResponse{detailed}, notionService.Schema())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", testCase.TestCaseKey+".feature"))
+	c.Data(http.StatusOK, featureContentType, []byte(feature))
+}
+
+// ImportFeature godoc
+// @Summary Import test cases from a Gherkin feature file
+// @Description Create or update test cases from scenarios. Returns a dry-run diff unless apply=true.
+// @Tags gherkin
+// @Accept plain
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file false "Feature file (or send it as the request body)"
+// @Param apply query bool false "Write the changes to Notion" default(false)
+// @Param delete_missing query bool false "Remove existing steps missing from the import" default(false)
+// @Success 200 {object} models.ImportResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/test-cases/feature [post]
+func (h *GherkinHandler) ImportFeature(c *gin.Context) {
+	data, err := readReport(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Failed to read feature file",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	scenarios, err := services.ParseFeature(string(data))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid feature file",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	dryRun := c.Query("apply") != "true"
+	result, err := h.importService.WithContext(c.Request.Context()).ImportFeature(scenarios, services.ImportOptions{DryRun: dryRun, DeleteMissing: c.Query("delete_missing") == "true"})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to import feature file",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	message := "Import diff computed successfully"
+	if !dryRun {
+		message = "Test cases imported successfully"
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    result,
+		Message: message,
+	})
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"demo-notion-api/graph"
+	"demo-notion-api/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+)
+
+type GraphQLHandler struct {
+	schema   graphql.Schema
+	projects *services.ProjectRegistry
+}
+
+func NewGraphQLHandler(schema graphql.Schema, projects *services.ProjectRegistry) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema:   schema,
+		projects: projects,
+	}
+}
+
+// GraphQLRequest is the body of a GraphQL POST request
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Query godoc
+// @Summary GraphQL endpoint
+// @Description Query test cases, blocks, tables, steps and test runs; nested data is only read from Notion when requested
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body GraphQLRequest true "GraphQL query"
+// @Success 200 {object} graphql.Result
+// @Failure 400 {object} ErrorResponse
+// @Router /api/graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var request GraphQLRequest
+	if c.Request.Method == http.MethodGet {
+		request.Query = c.Query("query")
+		request.OperationName = c.Query("operationName")
+	} else if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid GraphQL request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if request.Query == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Missing query",
+			Message: "query is required",
+		})
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  request.Query,
+		OperationName:  request.OperationName,
+		VariableValues: request.Variables,
+		Context:        graph.NewContext(c.Request.Context(), h.projects),
+	})
+
+	c.JSON(http.StatusOK, result)
+}
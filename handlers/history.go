@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"demo-notion-api/services"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HistoryHandler struct {
+	historyService *services.HistoryService
+	projects       *services.ProjectRegistry
+}
+
+func NewHistoryHandler(historyService *services.HistoryService, projects *services.ProjectRegistry) *HistoryHandler {
+	return &HistoryHandler{
+		historyService: historyService,
+		projects:       projects,
+	}
+}
+
+// GetHistory godoc
+// @Summary List test case versions
+// @Description List the versions recorded by the sync whenever the test case was edited
+// @Tags history
+// @Produce json
+// @Param testCaseKey path string true "Test case key (e.g., 01001)"
+// @Success 200 {object} models.HistoryResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/test-cases/{testCaseKey}/history [get]
+func (h *HistoryHandler) GetHistory(c *gin.Context) {
+	history, err := h.historyService.History(h.project(c), c.Param("testCaseKey"))
+	if err != nil {
+		respondHistoryError(c, "Failed to get history", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    history,
+		Message: "History retrieved successfully",
+	})
+}
+
+// GetVersion godoc
+// @Summary Get a test case version
+// @Description Get the properties, block tree and step table of one version
+// @Tags history
+// @Produce json
+// @Param testCaseKey path string true "Test case key (e.g., 01001)"
+// @Param version path int true "Version number"
+// @Success 200 {object} models.TestCaseVersion
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/test-cases/{testCaseKey}/history/{version} [get]
+func (h *HistoryHandler) GetVersion(c *gin.Context) {
+	number, ok := versionParam(c, "version")
+	if !ok {
+		return
+	}
+
+	version, err := h.historyService.Version(h.project(c), c.Param("testCaseKey"), number)
+	if err != nil {
+		respondHistoryError(c, "Failed to get version", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    version,
+		Message: "Version retrieved successfully",
+	})
+}
+
+// GetVersionDiff godoc
+// @Summary Compare two test case versions
+// @Description Show changed properties, steps and text blocks, each with the version and editor that changed it
+// @Tags history
+// @Produce json
+// @Param testCaseKey path string true "Test case key (e.g., 01001)"
+// @Param version path int true "Older version number"
+// @Param other path int true "Newer version number"
+// @Success 200 {object} models.VersionDiff
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/test-cases/{testCaseKey}/history/{version}/diff/{other} [get]
+func (h *HistoryHandler) GetVersionDiff(c *gin.Context) {
+	from, ok := versionParam(c, "version")
+	if !ok {
+		return
+	}
+	to, ok := versionParam(c, "other")
+	if !ok {
+		return
+	}
+
+	diff, err := h.historyService.Diff(h.project(c), c.Param("testCaseKey"), from, to)
+	if err != nil {
+		respondHistoryError(c, "Failed to compare versions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    diff,
+		Message: "Versions compared successfully",
+	})
+}
+
+// Helper methods
+
+func (h *HistoryHandler) project(c *gin.Context) string {
+	if name := c.Param("project"); name != "" {
+		return name
+	}
+	return h.projects.Default().Name
+}
+
+// Helper functions
+
+func versionParam(c *gin.Context, name string) (int, bool) {
+	number, err := strconv.Atoi(c.Param(name))
+	if err != nil || number < 1 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid version",
+			Message: "version must be a positive number",
+		})
+		return 0, false
+	}
+	return number, true
+}
+
+func respondHistoryError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrHistoryNotFound), errors.Is(err, services.ErrVersionNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidVersionRange):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
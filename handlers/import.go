@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"demo-notion-api/services"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImportHandler struct {
+	importService *services.ImportService
+}
+
+func NewImportHandler(importService *services.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+// ImportTestCases godoc
+// @Summary Import test cases from a spreadsheet
+// @Description Import a CSV or XLSX file in the export layout (one row per step). Returns a dry-run diff unless apply=true.
+// @Tags testcases
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file"
+// @Param format query string false "File format (csv or xlsx); defaults to the file extension"
+// @Param apply query bool false "Write the changes to Notion" default(false)
+// @Param delete_missing query bool false "Remove existing steps missing from the import" default(false)
+// @Success 200 {object} models.ImportResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/test-cases/import [post]
+func (h *ImportHandler) ImportTestCases(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Missing file",
+			Message: "A spreadsheet must be uploaded in the file form field",
+		})
+		return
+	}
+
+	format := c.Query("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+	}
+	if format != "csv" && format != "xlsx" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid format",
+			Message: "format must be csv or xlsx",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Failed to open file",
+			Message: err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	sheets, err := services.ReadSpreadsheet(file, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Failed to read spreadsheet",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	dryRun := c.Query("apply") != "true"
+	result, err := h.importService.WithContext(c.Request.Context()).Import(sheets, services.ImportOptions{DryRun: dryRun, DeleteMissing: c.Query("delete_missing") == "true"})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to import test cases",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	message := "Import diff computed successfully"
+	if !dryRun {
+		message = "Test cases imported successfully"
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    result,
+		Message: message,
+	})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"demo-notion-api/models"
+	"demo-notion-api/services"
+	"encoding/xml"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JUnitHandler struct {
+	junitService *services.JUnitService
+}
+
+func NewJUnitHandler(junitService *services.JUnitService) *JUnitHandler {
+	return &JUnitHandler{
+		junitService: junitService,
+	}
+}
+
+// GetTestCasesJUnit godoc
+// @Summary Export test case results as JUnit XML
+// @Description Report every test case as a JUnit testcase with its status mapped to pass, fail or skipped.
+// @Description Test cases that cannot be read completely are reported as errors.
+// @Tags testcases
+// @Produce xml
+// @Param strict query bool false "Fail when any table cannot be read" default(false)
+// @Success 200 {object} models.JUnitTestSuites
+// @Failure 500 {object} ErrorResponse
+// @Router /api/test-cases/junit.xml [get]
+func (h *JUnitHandler) GetTestCasesJUnit(c *gin.Context) {
+	report, testCases, err := h.junitService.WithContext(c.Request.Context()).TestCasesReport()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to build JUnit report",
+			Message: err.Error(),
+		})
+		return
+	}
+	if !checkIncomplete(c, "Failed to build JUnit report", services.CheckComplete(testCases)) {
+		return
+	}
+
+	writeJUnit(c, report)
+}
+
+// GetPlanJUnit godoc
+// @Summary Export plan results as JUnit XML
+// @Description Report the test cases of a plan as JUnit XML with one testsuite per suite
+// @Tags plans
+// @Produce xml
+// @Param plan path string true "Plan name"
+// @Param strict query bool false "Fail when any table cannot be read" default(false)
+// @Success 200 {object} models.JUnitTestSuites
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/plans/{plan}/junit.xml [get]
+func (h *JUnitHandler) GetPlanJUnit(c *gin.Context) {
+	report, testCases, err := h.junitService.WithContext(c.Request.Context()).PlanReport(c.Param("plan"))
+	if err != nil {
+		respondSuiteError(c, "Failed to build JUnit report", err)
+		return
+	}
+	if !checkIncomplete(c, "Failed to build JUnit report", services.CheckComplete(testCases)) {
+		return
+	}
+
+	writeJUnit(c, report)
+}
+
+func writeJUnit(c *gin.Context, report *models.JUnitTestSuites) {
+	body, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to encode JUnit report",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", append([]byte(xml.Header), body...))
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"demo-notion-api/config"
 	"demo-notion-api/models"
 	"demo-notion-api/services"
 	"net/http"
@@ -10,12 +9,12 @@ import (
 )
 
 type NotionHandler struct {
-	notionService *services.NotionService
+	projects *services.ProjectRegistry
 }
 
-func NewNotionHandler(cfg *config.Config) *NotionHandler {
+func NewNotionHandler(projects *services.ProjectRegistry) *NotionHandler {
 	return &NotionHandler{
-		notionService: services.NewNotionService(cfg),
+		projects: projects,
 	}
 }
 
@@ -25,23 +24,46 @@ func NewNotionHandler(cfg *config.Config) *NotionHandler {
 // @Tags testcases
 // @Accept json
 // @Produce json
+// @Param status query string false "Statuses, comma-separated"
+// @Param test_date_from query string false "Earliest test date (YYYY-MM-DD)"
+// @Param test_date_to query string false "Latest test date (YYYY-MM-DD)"
+// @Param key_prefix query string false "Key prefix"
+// @Param key_from query string false "First key of a key range"
+// @Param key_to query string false "Last key of a key range"
+// @Param title query string false "Text the title contains"
+// @Param tag query string false "Tags the test case must all have, comma-separated"
+// @Param assignee query string false "Assignee name"
+// @Param sort query string false "Sort field" default(last_edited)
+// @Param order query string false "asc or desc" default(asc)
+// @Param limit query int false "Page size"
+// @Param cursor query string false "Cursor of the next page"
 // @Success 200 {array} models.TestCaseResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/test-cases [get]
 func (h *NotionHandler) SearchTestCases(c *gin.Context) {
-	testCases, err := h.notionService.SearchTestCases()
+	query, err := testCaseQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to search test cases",
-			Message: err.Error(),
-		})
+		respondQueryError(c, "Invalid query", err)
+		return
+	}
+
+	notionService, ok := h.notionService(c)
+	if !ok {
+		return
+	}
+
+	testCases, pagination, err := notionService.QueryTestCases(query)
+	if err != nil {
+		respondQueryError(c, "Failed to search test cases", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, APIResponse{
-		Success: true,
-		Data:    testCases,
-		Message: "Test cases retrieved successfully",
+		Success:    true,
+		Data:       testCases,
+		Message:    "Test cases retrieved successfully",
+		Pagination: &pagination,
 	})
 }
 
@@ -68,8 +90,13 @@ func (h *NotionHandler) GetTestCaseBlocks(c *gin.Context) {
 		return
 	}
 
+	notionService, ok := h.notionService(c)
+	if !ok {
+		return
+	}
+
 	// Find the test case by key
-	testCase, err := h.notionService.GetTestCaseByKey(testCaseKey)
+	testCase, err := notionService.GetTestCaseByKey(testCaseKey)
 	if err != nil {
 		c.JSON(http.StatusNotFound, ErrorResponse{
 			Error:   "Test case not found",
@@ -83,9 +110,9 @@ func (h *NotionHandler) GetTestCaseBlocks(c *gin.Context) {
 
 	var blocks []models.BlockResponse
 	if blockType == "table" {
-		blocks, err = h.notionService.GetTableBlocks(testCase.PageID)
+		blocks, err = notionService.GetTableBlocks(testCase.PageID)
 	} else {
-		blocks, err = h.notionService.GetPageBlocks(testCase.PageID)
+		blocks, err = notionService.GetPageBlocks(testCase.PageID)
 	}
 
 	if err != nil {
@@ -130,7 +157,12 @@ func (h *NotionHandler) GetBlockDetails(c *gin.Context) {
 		return
 	}
 
-	block, err := h.notionService.GetBlockDetails(blockID)
+	notionService, ok := h.notionService(c)
+	if !ok {
+		return
+	}
+
+	block, err := notionService.GetBlockDetails(blockID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Failed to get block details",
@@ -148,35 +180,85 @@ func (h *NotionHandler) GetBlockDetails(c *gin.Context) {
 
 // GetDetailedTestCases godoc
 // @Summary Get detailed test cases with table data
-// @Description Search for test cases and include all table data in one response
+// @Description Search for test cases and include all table data in one response.
+// @Description Accepts the same filter, sort and pagination parameters as /api/test-cases;
+// @Description table data is only read for the test cases on the requested page.
+// @Description Tables that cannot be read are listed in the errors of their test case
+// @Description and the response is marked partial; with strict=true the request fails instead.
 // @Tags testcases
 // @Accept json
 // @Produce json
+// @Param strict query bool false "Fail when any table cannot be read" default(false)
 // @Success 200 {array} models.DetailedTestCaseResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/test-cases/detailed [get]
 func (h *NotionHandler) GetDetailedTestCases(c *gin.Context) {
-	detailedTestCases, err := h.notionService.GetDetailedTestCases()
+	query, err := testCaseQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to get detailed test cases",
-			Message: err.Error(),
-		})
+		respondQueryError(c, "Invalid query", err)
 		return
 	}
 
+	notionService, ok := h.notionService(c)
+	if !ok {
+		return
+	}
+
+	detailedTestCases, pagination, err := notionService.QueryDetailedTestCases(query)
+	if err != nil {
+		respondQueryError(c, "Failed to get detailed test cases", err)
+		return
+	}
+
+	incomplete := services.CheckComplete(detailedTestCases)
+	if incomplete != nil && c.Query("strict") == "true" {
+		respondQueryError(c, "Failed to get detailed test cases", incomplete)
+		return
+	}
+
+	message := "Detailed test cases retrieved successfully"
+	if incomplete != nil {
+		message = "Detailed test cases retrieved with errors"
+	}
+
 	c.JSON(http.StatusOK, APIResponse{
-		Success: true,
-		Data:    detailedTestCases,
-		Message: "Detailed test cases retrieved successfully",
+		Success:    true,
+		Data:       detailedTestCases,
+		Message:    message,
+		Pagination: &pagination,
+		Partial:    incomplete != nil,
 	})
 }
 
+// notionService resolves the service of the :project route parameter, or the
+// default project on unscoped routes, bound to the request context. It writes
+// a 404 response and returns false when the project does not exist.
+func (h *NotionHandler) notionService(c *gin.Context) (*services.NotionService, bool) {
+	name := c.Param("project")
+	if name == "" {
+		return h.projects.Default().NotionService.WithContext(c.Request.Context()), true
+	}
+
+	project, err := h.projects.Get(name)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Project not found",
+			Message: err.Error(),
+		})
+		return nil, false
+	}
+
+	return project.NotionService.WithContext(c.Request.Context()), true
+}
+
 // Response structures for API
 type APIResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data"`
-	Message string      `json:"message"`
+	Success    bool               `json:"success"`
+	Data       interface{}        `json:"data"`
+	Message    string             `json:"message"`
+	Pagination *models.Pagination `json:"pagination,omitempty"`
+	Partial    bool               `json:"partial,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListProjects godoc
+// @Summary List projects
+// @Description List the Notion workspaces and databases served by this deployment
+// @Tags projects
+// @Produce json
+// @Success 200 {array} models.ProjectInfo
+// @Router /api/projects [get]
+func (h *NotionHandler) ListProjects(c *gin.Context) {
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    h.projects.List(),
+		Message: "Projects retrieved successfully",
+	})
+}
+
+// SearchAllTestCases godoc
+// @Summary Search test cases across all projects
+// @Description Merge the test cases of every project into one listing
+// @Tags projects
+// @Produce json
+// @Success 200 {array} models.TestCaseResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/projects/test-cases [get]
+func (h *NotionHandler) SearchAllTestCases(c *gin.Context) {
+	testCases, err := h.projects.SearchAllTestCases(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to search test cases",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    testCases,
+		Message: "Test cases retrieved successfully",
+	})
+}
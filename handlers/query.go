@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"demo-notion-api/models"
+	"demo-notion-api/services"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testCaseQuery reads the filter, sort and pagination parameters of a test case listing.
+// Multi-valued parameters may be repeated or given as comma-separated lists.
+func testCaseQuery(c *gin.Context) (models.TestCaseQuery, error) {
+	q := models.TestCaseQuery{
+		Statuses:     queryList(c, "status"),
+		TestDateFrom: c.Query("test_date_from"),
+		TestDateTo:   c.Query("test_date_to"),
+		KeyPrefix:    c.Query("key_prefix"),
+		KeyFrom:      c.Query("key_from"),
+		KeyTo:        c.Query("key_to"),
+		Title:        c.Query("title"),
+		Tags:         queryList(c, "tag"),
+		Assignee:     c.Query("assignee"),
+		Sort:         c.Query("sort"),
+		Cursor:       c.Query("cursor"),
+	}
+
+	switch order := strings.ToLower(c.DefaultQuery("order", "asc")); order {
+	case "asc":
+	case "desc":
+		q.Descending = true
+	default:
+		return q, fmt.Errorf("%w: order must be asc or desc", services.ErrInvalidQuery)
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return q, fmt.Errorf("%w: limit must be a positive number", services.ErrInvalidQuery)
+		}
+		q.Limit = limit
+	}
+
+	return q, services.ValidateQuery(q)
+}
+
+func queryList(c *gin.Context, name string) []string {
+	var values []string
+	for _, value := range c.QueryArray(name) {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
+}
+
+// PartialHeader marks file responses built from test cases that could not be
+// read completely; JSON responses carry the partial field instead
+const PartialHeader = "X-Partial"
+
+// checkIncomplete handles the CheckComplete error of a file response: with
+// strict=true it fails the request like /test-cases/detailed, otherwise it
+// sets the PartialHeader. It returns false when it has responded.
+func checkIncomplete(c *gin.Context, message string, incomplete error) bool {
+	if incomplete == nil {
+		return true
+	}
+	if c.Query("strict") == "true" {
+		respondQueryError(c, message, incomplete)
+		return false
+	}
+	c.Header(PartialHeader, "true")
+	return true
+}
+
+// respondQueryError reports invalid query parameters as 400 and anything else as 500
+func respondQueryError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, services.ErrInvalidQuery) {
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"demo-notion-api/auth"
+	"demo-notion-api/models"
+	"demo-notion-api/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReadinessHandler struct {
+	readinessService *services.ReadinessService
+	// authEnabled limits the details of the report to authenticated callers
+	authEnabled bool
+}
+
+func NewReadinessHandler(readinessService *services.ReadinessService, authEnabled bool) *ReadinessHandler {
+	return &ReadinessHandler{
+		readinessService: readinessService,
+		authEnabled:      authEnabled,
+	}
+}
+
+// GetReadiness godoc
+// @Summary Readiness probe
+// @Description Check per project that a Notion API key is configured, that Notion accepts it
+// @Description (users/me), that the configured database can be read and that the last sync
+// @Description is recent. Returns 503 when any component check fails. Reports are reused
+// for READY_CACHE_TTL. With authentication enabled, callers without credentials only
+// get the statuses, without messages and latencies.
+// @Tags health
+// @Produce json
+// @Success 200 {object} models.ReadinessReport
+// @Failure 503 {object} models.ReadinessReport
+// @Router /api/ready [get]
+func (h *ReadinessHandler) GetReadiness(c *gin.Context) {
+	report := h.readinessService.Check(c.Request.Context())
+	if _, known := auth.IdentityFrom(c.Request.Context()); h.authEnabled && !known {
+		report = statusesOnly(report)
+	}
+
+	status := http.StatusOK
+	if report.Status != models.ReadinessReady {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// Helper functions
+
+// statusesOnly drops the messages and latencies of a report, which name the
+// Notion bot and databases and carry upstream errors
+func statusesOnly(report models.ReadinessReport) models.ReadinessReport {
+	components := make([]models.ComponentCheck, len(report.Components))
+	for i, check := range report.Components {
+		components[i] = models.ComponentCheck{
+			Name:    check.Name,
+			Project: check.Project,
+			Status:  check.Status,
+		}
+	}
+	report.Components = components
+	return report
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"demo-notion-api/services"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxReportSize limits the request body of uploaded test reports to 32 MiB
+const maxReportSize = 32 << 20
+
+type ResultsHandler struct {
+	resultsService *services.ResultsService
+}
+
+func NewResultsHandler(resultsService *services.ResultsService) *ResultsHandler {
+	return &ResultsHandler{
+		resultsService: resultsService,
+	}
+}
+
+// IngestJUnit godoc
+// @Summary Update test case statuses from a JUnit report
+// @Description Match JUnit testcase names to test case keys and write Status and Test Date to Notion
+// @Tags results
+// @Accept xml
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file false "JUnit XML report (or send the XML as the request body)"
+// @Param dry_run query bool false "Only report what would be updated" default(false)
+// @Success 200 {object} models.JUnitIngestResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/results/junit [post]
+func (h *ResultsHandler) IngestJUnit(c *gin.Context) {
+	data, err := readReport(c)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+			Error:   "Report too large",
+			Message: fmt.Sprintf("the request body must not exceed %d MiB", maxReportSize>>20),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Failed to read report",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	report, err := services.ParseJUnit(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid JUnit report",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.resultsService.WithContext(c.Request.Context()).IngestJUnit(report, c.Query("dry_run") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to ingest JUnit report",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    result,
+		Message: "JUnit report ingested successfully",
+	})
+}
+
+// readReport returns the uploaded file field of a multipart request, or the raw
+// request body. Bodies over maxReportSize fail with an *http.MaxBytesError.
+func readReport(c *gin.Context) ([]byte, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxReportSize)
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+
+	return io.ReadAll(c.Request.Body)
+}
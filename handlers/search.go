@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"demo-notion-api/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultSearchLimit caps the number of search results when no limit is given
+const defaultSearchLimit = 20
+
+type SearchHandler struct {
+	searchService *services.SearchService
+	projects      *services.ProjectRegistry
+}
+
+func NewSearchHandler(searchService *services.SearchService, projects *services.ProjectRegistry) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		projects:      projects,
+	}
+}
+
+// Search godoc
+// @Summary Full-text search
+// @Description Search titles, text blocks and table cells of the synced test cases; every word of q must appear in the test case
+// @Tags search
+// @Produce json
+// @Param q query string true "Search query"
+// @Param project query string false "Only search this project"
+// @Param limit query int false "Maximum number of results" default(20)
+// @Success 200 {object} models.SearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Missing query",
+			Message: "q is required",
+		})
+		return
+	}
+
+	limit := defaultSearchLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid limit",
+				Message: "limit must be a positive number",
+			})
+			return
+		}
+		limit = parsed
+	}
+
+	project := c.Param("project")
+	if project == "" {
+		project = c.Query("project")
+	}
+	if project != "" {
+		if _, err := h.projects.Get(project); err != nil {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Project not found",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    h.searchService.Search(query, project, limit),
+		Message: "Search completed successfully",
+	})
+}
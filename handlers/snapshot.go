@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"demo-notion-api/services"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultTrendDays is the range of the trend when from is not given
+const defaultTrendDays = 30
+
+type SnapshotHandler struct {
+	snapshotService *services.SnapshotService
+	projects        *services.ProjectRegistry
+}
+
+func NewSnapshotHandler(snapshotService *services.SnapshotService, projects *services.ProjectRegistry) *SnapshotHandler {
+	return &SnapshotHandler{
+		snapshotService: snapshotService,
+		projects:        projects,
+	}
+}
+
+// GetTrend godoc
+// @Summary Get status trend
+// @Description Status counts per day from the daily status snapshots, for burndown charts
+// @Tags stats
+// @Produce json
+// @Param from query string false "First date (YYYY-MM-DD), defaults to 30 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} models.TrendResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/stats/trend [get]
+func (h *SnapshotHandler) GetTrend(c *gin.Context) {
+	to := time.Now().UTC()
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid to date",
+				Message: "to must be a date in YYYY-MM-DD format",
+			})
+			return
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -defaultTrendDays)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid from date",
+				Message: "from must be a date in YYYY-MM-DD format",
+			})
+			return
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid date range",
+			Message: "from must not be after to",
+		})
+		return
+	}
+
+	project := c.Param("project")
+	if project == "" {
+		project = h.projects.Default().Name
+	}
+
+	trend, err := h.snapshotService.Trend(project, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrProjectNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, ErrorResponse{
+			Error:   "Failed to get trend",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    trend,
+		Message: "Trend retrieved successfully",
+	})
+}
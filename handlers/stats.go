@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"demo-notion-api/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetStats godoc
+// @Summary Get test progress statistics
+// @Description Counts by status, test date bucket, tag and assignee, pass rate, executed and remaining counts, and step-level totals.
+// @Description When tables cannot be read the step totals are incomplete and the response is marked partial;
+// @Description with strict=true the request fails instead.
+// @Tags stats
+// @Produce json
+// @Param bucket query string false "Test date bucket (day, week or month)" default(day)
+// @Param strict query bool false "Fail when any table cannot be read" default(false)
+// @Success 200 {object} models.StatsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/stats [get]
+func (h *NotionHandler) GetStats(c *gin.Context) {
+	bucket := c.DefaultQuery("bucket", services.BucketDay)
+	if !services.ValidBucket(bucket) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid bucket",
+			Message: "bucket must be day, week or month",
+		})
+		return
+	}
+
+	notionService, ok := h.notionService(c)
+	if !ok {
+		return
+	}
+
+	detailed, err := notionService.GetDetailedTestCases()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get statistics",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	incomplete := services.CheckComplete(detailed)
+	if incomplete != nil && c.Query("strict") == "true" {
+		respondQueryError(c, "Failed to get statistics", incomplete)
+		return
+	}
+
+	message := "Statistics retrieved successfully"
+	if incomplete != nil {
+		message = "Statistics retrieved with errors"
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    services.ComputeStats(notionService.Schema(), detailed, bucket),
+		Message: message,
+		Partial: incomplete != nil,
+	})
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"demo-notion-api/models"
+	"demo-notion-api/services"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errStreamStopped ends a stream that was cut short by an error event or a
+// client that went away
+var errStreamStopped = errors.New("stream stopped")
+
+// Stream formats
+const (
+	streamFormatSSE    = "sse"
+	streamFormatNDJSON = "ndjson"
+)
+
+// StreamDetailedTestCases godoc
+// @Summary Stream detailed test cases with table data
+// @Description Sends each detailed test case as soon as its table data is read,
+// @Description followed by a progress event, and ends with a summary of the errors
+// @Description per test case. Accepts the same parameters as /api/test-cases/detailed.
+// @Description The format is Server-Sent Events unless format=ndjson is given or the
+// @Description Accept header asks for application/x-ndjson. With strict=true the stream
+// @Description ends with an error event at the first test case that cannot be read completely.
+// @Tags testcases
+// @Produce text/event-stream
+// @Produce application/x-ndjson
+// @Param format query string false "sse or ndjson" default(sse)
+// @Param strict query bool false "Stop at the first table that cannot be read" default(false)
+// @Success 200 {object} models.StreamEvent
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/test-cases/detailed/stream [get]
+func (h *NotionHandler) StreamDetailedTestCases(c *gin.Context) {
+	format, err := streamFormat(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid format",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	query, err := testCaseQuery(c)
+	if err != nil {
+		respondQueryError(c, "Invalid query", err)
+		return
+	}
+
+	notionService, ok := h.notionService(c)
+	if !ok {
+		return
+	}
+
+	// The response starts with the first test case, so that query errors
+	// still get a JSON error response
+	started := false
+	start := func() {
+		if started {
+			return
+		}
+		if format == streamFormatNDJSON {
+			c.Header("Content-Type", "application/x-ndjson")
+		} else {
+			c.Header("Content-Type", "text/event-stream")
+		}
+		c.Header("Cache-Control", "no-cache")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+		started = true
+	}
+
+	send := func(event string, data any) bool {
+		if err := writeStreamEvent(c.Writer, format, event, data); err != nil {
+			return false
+		}
+		c.Writer.Flush()
+		return true
+	}
+
+	summary := models.StreamSummary{Errors: []models.TestCaseError{}}
+	strict := c.Query("strict") == "true"
+	ctx := c.Request.Context()
+
+	pagination, err := notionService.StreamDetailedTestCases(query, func(detailed models.DetailedTestCaseResponse, progress models.StreamProgress) error {
+		start()
+		summary.Total = progress.Total
+		if len(detailed.Errors) > 0 {
+			summary.Failed++
+			summary.Errors = append(summary.Errors, detailed.Errors...)
+		} else {
+			summary.Succeeded++
+		}
+
+		if strict && len(detailed.Errors) > 0 {
+			send(models.StreamEventError, ErrorResponse{
+				Error:   "Failed to get detailed test cases",
+				Message: fmt.Sprintf("%v: test case %s: %s", services.ErrIncompleteTestCases, detailed.TestCaseKey, detailed.Errors[0].Message),
+			})
+			return errStreamStopped
+		}
+
+		if !send(models.StreamEventTestCase, detailed) || !send(models.StreamEventProgress, progress) {
+			return errStreamStopped
+		}
+		return ctx.Err()
+	})
+	if err != nil {
+		if !started {
+			respondQueryError(c, "Failed to get detailed test cases", err)
+		}
+		return
+	}
+
+	start()
+	summary.Pagination = pagination
+	send(models.StreamEventSummary, summary)
+}
+
+// Helper functions
+
+// streamFormat picks the stream format from the format parameter, then the Accept header
+func streamFormat(c *gin.Context) (string, error) {
+	switch format := strings.ToLower(c.Query("format")); format {
+	case streamFormatSSE, streamFormatNDJSON:
+		return format, nil
+	case "":
+	default:
+		return "", fmt.Errorf("format must be %s or %s", streamFormatSSE, streamFormatNDJSON)
+	}
+
+	if strings.Contains(c.GetHeader("Accept"), "application/x-ndjson") {
+		return streamFormatNDJSON, nil
+	}
+	return streamFormatSSE, nil
+}
+
+// writeStreamEvent writes one event as an SSE message or an NDJSON line
+func writeStreamEvent(w io.Writer, format, event string, data any) error {
+	if format == streamFormatNDJSON {
+		line, err := json.Marshal(models.StreamEvent{Event: event, Data: data})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", line)
+		return err
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
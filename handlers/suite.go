@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"demo-notion-api/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SuiteHandler struct {
+	suiteService *services.SuiteService
+}
+
+func NewSuiteHandler(suiteService *services.SuiteService) *SuiteHandler {
+	return &SuiteHandler{
+		suiteService: suiteService,
+	}
+}
+
+// ListSuites godoc
+// @Summary List test suites
+// @Description List all test suites defined in the suites file
+// @Tags suites
+// @Produce json
+// @Success 200 {array} models.TestSuite
+// @Router /api/suites [get]
+func (h *SuiteHandler) ListSuites(c *gin.Context) {
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    h.suiteService.ListSuites(),
+		Message: "Suites retrieved successfully",
+	})
+}
+
+// GetSuiteTestCases godoc
+// @Summary Get test cases of a suite
+// @Description Resolve the test cases that belong to a suite by keys, key ranges and filters
+// @Tags suites
+// @Produce json
+// @Param suite path string true "Suite name"
+// @Success 200 {object} models.SuiteTestCasesResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/suites/{suite}/test-cases [get]
+func (h *SuiteHandler) GetSuiteTestCases(c *gin.Context) {
+	result, err := h.suiteService.WithContext(c.Request.Context()).GetSuiteTestCases(c.Param("suite"))
+	if err != nil {
+		respondSuiteError(c, "Failed to get suite test cases", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    result,
+		Message: "Suite test cases retrieved successfully",
+	})
+}
+
+// ListPlans godoc
+// @Summary List test plans
+// @Description List all test plans defined in the suites file
+// @Tags plans
+// @Produce json
+// @Success 200 {array} models.TestPlan
+// @Router /api/plans [get]
+func (h *SuiteHandler) ListPlans(c *gin.Context) {
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    h.suiteService.ListPlans(),
+		Message: "Plans retrieved successfully",
+	})
+}
+
+// GetPlanTestCases godoc
+// @Summary Get test cases of a plan
+// @Description Resolve the test cases of every suite referenced by a plan
+// @Tags plans
+// @Produce json
+// @Param plan path string true "Plan name"
+// @Success 200 {object} models.PlanTestCasesResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/plans/{plan}/test-cases [get]
+func (h *SuiteHandler) GetPlanTestCases(c *gin.Context) {
+	result, err := h.suiteService.WithContext(c.Request.Context()).GetPlanTestCases(c.Param("plan"))
+	if err != nil {
+		respondSuiteError(c, "Failed to get plan test cases", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    result,
+		Message: "Plan test cases retrieved successfully",
+	})
+}
+
+// GetPlanProgress godoc
+// @Summary Get progress of a plan
+// @Description Aggregate status counts and pass rate for a plan and each of its suites
+// @Tags plans
+// @Produce json
+// @Param plan path string true "Plan name"
+// @Success 200 {object} models.PlanProgressResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/plans/{plan}/progress [get]
+func (h *SuiteHandler) GetPlanProgress(c *gin.Context) {
+	result, err := h.suiteService.WithContext(c.Request.Context()).GetPlanProgress(c.Param("plan"))
+	if err != nil {
+		respondSuiteError(c, "Failed to get plan progress", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    result,
+		Message: "Plan progress retrieved successfully",
+	})
+}
+
+func respondSuiteError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, services.ErrSuiteNotFound) || errors.Is(err, services.ErrPlanNotFound) {
+		status = http.StatusNotFound
+	}
+
+	c.JSON(status, ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Transport logs every upstream call and forwards the request ID of the
+// request context in the X-Request-ID header
+type Transport struct {
+	// Upstream names the service in log records
+	Upstream string
+	// Base performs the calls; http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+// upstreamRequestIDHeaders are checked in order for the ID the upstream gave the call
+var upstreamRequestIDHeaders = []string{"X-Notion-Request-Id", "X-Request-Id"}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if id := RequestID(ctx); id != "" {
+		req = req.Clone(ctx)
+		req.Header.Set(RequestIDHeader, id)
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	attrs := []any{
+		slog.String("upstream", t.Upstream),
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Duration("duration", time.Since(start)),
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "upstream request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	for _, header := range upstreamRequestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			attrs = append(attrs, slog.String("upstream_request_id", id))
+			break
+		}
+	}
+
+	level := slog.LevelInfo
+	if resp.StatusCode >= 400 {
+		level = slog.LevelWarn
+	}
+	slog.Log(ctx, level, "upstream request", attrs...)
+	return resp, nil
+}
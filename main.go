@@ -1,30 +1,157 @@
 package main
 
 import (
+	"context"
+	"demo-notion-api/auth"
 	"demo-notion-api/config"
+	"demo-notion-api/graph"
+	"demo-notion-api/grpcserver"
 	"demo-notion-api/handlers"
-	"log"
+	"demo-notion-api/logging"
+	"demo-notion-api/metrics"
+	"demo-notion-api/proto/notionpb"
+	"demo-notion-api/ratelimit"
+	"demo-notion-api/services"
+	"demo-notion-api/tracing"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
 	// Load configuration
 	cfg := config.Load()
 
-	// Create Gin router
-	r := gin.Default()
+	// Structured logs; records of a request carry its request ID
+	if err := logging.Setup(os.Stdout, cfg.LogLevel, cfg.LogFormat); err != nil {
+		fatal("Failed to configure logging", err)
+	}
+
+	// Spans per request and per Notion call, exported when TRACE_EXPORTER is set
+	shutdownTracing, err := tracing.Setup(cfg.TraceExporter, cfg.TraceEndpoint, cfg.TraceFile)
+	if err != nil {
+		fatal("Failed to configure tracing", err)
+	}
+
+	// SIGINT or SIGTERM stops background work and shuts the servers down
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Load and validate the test case schema
+	schema, err := config.LoadSchema(cfg.SchemaFile)
+	if err != nil {
+		fatal("Failed to load schema", err)
+	}
+	cfg.Schema = schema
+
+	// Create Gin router; requests are logged, measured per route and traced
+	r := gin.New()
+	r.Use(tracing.Middleware(), logging.Middleware(), metrics.Middleware(), gin.Recovery())
+
+	// Client IPs key the rate limits of anonymous callers, so X-Forwarded-For
+	// is only believed from the configured proxies
+	if err := r.SetTrustedProxies(cfg.TrustedProxyList()); err != nil {
+		fatal("Failed to configure trusted proxies", err)
+	}
 
-	r.Use(cors.New(cors.Config{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders: []string{"Origin", "Content-Type", "Accept", "Authorization"},
-	}))
+	// Browsers may only call the API from the configured origins
+	if origins := cfg.AllowedOrigins(); len(origins) > 0 {
+		r.Use(cors.New(cors.Config{
+			AllowOrigins:  origins,
+			AllowMethods:  []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization", auth.APIKeyHeader, logging.RequestIDHeader, "traceparent", "tracestate"},
+			ExposeHeaders: []string{logging.RequestIDHeader, handlers.PartialHeader},
+		}))
+	}
+
+	// Require API keys or bearer tokens unless authentication is turned off explicitly
+	var authenticator auth.Authenticator
+	if cfg.AuthDisabled {
+		slog.Warn("authentication is disabled, the API is open to anyone")
+	} else {
+		authFile, err := config.LoadAuth(cfg.AuthFile)
+		if err != nil {
+			fatal("Failed to load auth file", err)
+		}
+		authenticator, err = auth.NewAuthenticator(authFile)
+		if err != nil {
+			fatal("Failed to configure authentication", err)
+		}
+	}
+
+	// Build one Notion service per configured project
+	projects, err := services.NewProjectRegistry(cfg)
+	if err != nil {
+		fatal("Failed to load projects", err)
+	}
 
-	// Create notion handler with config
-	notionHandler := handlers.NewNotionHandler(cfg)
+	// Create notion handler with projects
+	notionHandler := handlers.NewNotionHandler(projects)
+
+	// Load suites for the default project; fails on an invalid suites file
+	suiteService, err := services.NewSuiteService(cfg, projects.Default().NotionService)
+	if err != nil {
+		fatal("Failed to load suites", err)
+	}
+	suiteHandler := handlers.NewSuiteHandler(suiteService)
+	exportHandler := handlers.NewExportHandler(services.NewExportService(suiteService))
+	junitHandler := handlers.NewJUnitHandler(services.NewJUnitService(suiteService))
+	importService := services.NewImportService(projects.Default().NotionService)
+	importHandler := handlers.NewImportHandler(importService)
+	gherkinHandler := handlers.NewGherkinHandler(projects.Default().NotionService, importService)
+	resultsHandler := handlers.NewResultsHandler(services.NewResultsService(projects.Default().NotionService))
+
+	// GraphQL schema over the same services
+	graphSchema, err := graph.NewSchema(suiteService)
+	if err != nil {
+		fatal("Failed to build GraphQL schema", err)
+	}
+	graphqlHandler := handlers.NewGraphQLHandler(graphSchema, projects)
+
+	// Snapshot every project's statuses daily for trend charts
+	snapshotService, err := services.NewSnapshotService(cfg, projects)
+	if err != nil {
+		fatal("Failed to load status snapshots", err)
+	}
+	snapshotService.Start(ctx)
+	snapshotHandler := handlers.NewSnapshotHandler(snapshotService, projects)
+
+	// Record a version of every test case whenever the sync sees it edited
+	historyService, err := services.NewHistoryService(cfg)
+	if err != nil {
+		fatal("Failed to load test case history", err)
+	}
+	syncService, err := services.NewSyncService(cfg, projects, historyService)
+	if err != nil {
+		fatal("Failed to configure sync", err)
+	}
+	historyHandler := handlers.NewHistoryHandler(historyService, projects)
+
+	// Index the synced test cases for full-text search
+	searchService := services.NewSearchService(historyService)
+	syncService.OnSync(searchService.Rebuild)
+	searchHandler := handlers.NewSearchHandler(searchService, projects)
+	syncService.Start()
+	metrics.RegisterSync(syncService.LastSynced)
+
+	// Readiness of Notion access and the sync mirror
+	readinessService, err := services.NewReadinessService(cfg, projects, syncService)
+	if err != nil {
+		fatal("Failed to configure readiness checks", err)
+	}
+	readinessHandler := handlers.NewReadinessHandler(readinessService, authenticator != nil)
 
 	// Health check endpoint
 	r.GET("/api/health", func(c *gin.Context) {
@@ -34,21 +161,208 @@ func main() {
 		})
 	})
 
-	// Routes
+	// Prometheus metrics; public like the health check
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// Roles per project from the policy file; needs authentication
+	policyFile, err := config.LoadPolicy(cfg.PolicyFile)
+	if err != nil {
+		fatal("Failed to load policy file", err)
+	}
+	if policyFile != nil && authenticator == nil {
+		fatal("Failed to configure roles", fmt.Errorf("policy file %s requires authentication", cfg.PolicyFile))
+	}
+	policy, err := auth.NewPolicy(policyFile, func(name string) string {
+		if project, err := projects.Get(name); err == nil {
+			return project.DatabaseID
+		}
+		return ""
+	})
+	if err != nil {
+		fatal("Failed to configure roles", err)
+	}
+	if policy == nil && authenticator != nil {
+		slog.Warn("no policy file, every authenticated caller has every role", slog.String("policy_file", cfg.PolicyFile))
+	}
+	defaultScope := auth.ProjectScope(projects.Default().Name)
+
+	// Token buckets per caller protect the shared Notion quota; routes that read
+	// every page and table also take from a smaller bucket of their own
+	limiter, err := ratelimit.New("request", cfg.RateLimit, cfg.RateLimitBurst)
+	if err != nil {
+		fatal("Failed to configure rate limits", err)
+	}
+	expensiveLimiter, err := ratelimit.New("expensive request", cfg.ExpensiveRateLimit, cfg.ExpensiveRateLimitBurst)
+	if err != nil {
+		fatal("Failed to configure rate limits", err)
+	}
+	expensive := ratelimit.Middleware(expensiveLimiter)
+
+	// Readiness probe; public, but limited since it calls Notion, and only
+	// authenticated callers see the details
+	r.GET("/api/ready", auth.OptionalMiddleware(authenticator), ratelimit.Middleware(limiter), readinessHandler.GetReadiness)
+
+	// Routes; /api/health and /api/ready above stay public
 	api := r.Group("/api")
+	if authenticator != nil {
+		api.Use(auth.Middleware(authenticator))
+	}
+	api.Use(ratelimit.Middleware(limiter))
+
+	// Reads of the default project
+	viewer := api.Group("", policy.Require(auth.RoleViewer, defaultScope))
+	{
+		viewer.GET("/test-cases", notionHandler.SearchTestCases)
+		viewer.GET("/test-cases/detailed", expensive, notionHandler.GetDetailedTestCases)
+		viewer.GET("/test-cases/detailed/stream", expensive, notionHandler.StreamDetailedTestCases)
+		viewer.GET("/test-cases/export", expensive, exportHandler.ExportTestCases)
+		viewer.GET("/test-cases/junit.xml", expensive, junitHandler.GetTestCasesJUnit)
+		viewer.GET("/test-cases/feature", expensive, gherkinHandler.GetFeature)
+		viewer.GET("/test-cases/:testCaseKey/feature", gherkinHandler.GetTestCaseFeature)
+		viewer.GET("/test-cases/:testCaseKey/blocks", notionHandler.GetTestCaseBlocks)
+		viewer.GET("/test-cases/:testCaseKey/history", historyHandler.GetHistory)
+		viewer.GET("/test-cases/:testCaseKey/history/:version", historyHandler.GetVersion)
+		viewer.GET("/test-cases/:testCaseKey/history/:version/diff/:other", historyHandler.GetVersionDiff)
+		viewer.GET("/blocks/:blockId", notionHandler.GetBlockDetails)
+
+		viewer.GET("/stats", expensive, notionHandler.GetStats)
+		viewer.GET("/stats/trend", snapshotHandler.GetTrend)
+
+		viewer.GET("/suites", suiteHandler.ListSuites)
+		viewer.GET("/suites/:suite/test-cases", suiteHandler.GetSuiteTestCases)
+		viewer.GET("/plans", suiteHandler.ListPlans)
+		viewer.GET("/plans/:plan/test-cases", suiteHandler.GetPlanTestCases)
+		viewer.GET("/plans/:plan/progress", suiteHandler.GetPlanProgress)
+		viewer.GET("/plans/:plan/junit.xml", expensive, junitHandler.GetPlanJUnit)
+	}
+
+	// Statuses and step results of the default project
+	tester := api.Group("", policy.Require(auth.RoleTester, defaultScope))
+	{
+		tester.POST("/results/junit", resultsHandler.IngestJUnit)
+	}
+
+	// Creating and rewriting test cases of the default project
+	lead := api.Group("", policy.Require(auth.RoleLead, defaultScope))
+	{
+		lead.POST("/test-cases/import", expensive, importHandler.ImportTestCases)
+		lead.POST("/test-cases/feature", expensive, gherkinHandler.ImportFeature)
+	}
+
+	// Reads across projects; scoped bindings do not cover these
+	global := api.Group("", policy.Require(auth.RoleViewer, auth.AllProjects))
+	{
+		global.GET("/projects", notionHandler.ListProjects)
+		global.GET("/projects/test-cases", expensive, notionHandler.SearchAllTestCases)
+		global.GET("/search", searchHandler.Search)
+
+		global.GET("/graphql", expensive, graphqlHandler.Query)
+		global.POST("/graphql", expensive, graphqlHandler.Query)
+	}
+
+	// Reads of the :project project
+	project := api.Group("/projects/:project", policy.Require(auth.RoleViewer, defaultScope))
 	{
-		api.GET("/test-cases", notionHandler.SearchTestCases)
-		api.GET("/test-cases/detailed", notionHandler.GetDetailedTestCases)
-		api.GET("/test-cases/:testCaseKey/blocks", notionHandler.GetTestCaseBlocks)
-		api.GET("/blocks/:blockId", notionHandler.GetBlockDetails)
+		project.GET("/test-cases", notionHandler.SearchTestCases)
+		project.GET("/test-cases/detailed", expensive, notionHandler.GetDetailedTestCases)
+		project.GET("/test-cases/detailed/stream", expensive, notionHandler.StreamDetailedTestCases)
+		project.GET("/test-cases/:testCaseKey/blocks", notionHandler.GetTestCaseBlocks)
+		project.GET("/test-cases/:testCaseKey/history", historyHandler.GetHistory)
+		project.GET("/test-cases/:testCaseKey/history/:version", historyHandler.GetVersion)
+		project.GET("/test-cases/:testCaseKey/history/:version/diff/:other", historyHandler.GetVersionDiff)
+		project.GET("/blocks/:blockId", notionHandler.GetBlockDetails)
+		project.GET("/stats", expensive, notionHandler.GetStats)
+		project.GET("/stats/trend", snapshotHandler.GetTrend)
+		project.GET("/search", searchHandler.Search)
 	}
 
+	// Serve the same test case operations over gRPC
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	listener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		fatal("Failed to listen on gRPC port "+grpcPort, err)
+	}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{grpcserver.UnaryLoggingInterceptor()}
+	streamInterceptors := []grpc.StreamServerInterceptor{grpcserver.StreamLoggingInterceptor()}
+	if authenticator != nil {
+		unaryInterceptors = append(unaryInterceptors,
+			grpcserver.UnaryAuthInterceptor(authenticator),
+			grpcserver.UnaryPolicyInterceptor(policy, projects.Default().Name),
+		)
+		streamInterceptors = append(streamInterceptors,
+			grpcserver.StreamAuthInterceptor(authenticator),
+			grpcserver.StreamPolicyInterceptor(policy, projects.Default().Name),
+		)
+	}
+	unaryInterceptors = append(unaryInterceptors, grpcserver.UnaryRateLimitInterceptor(limiter, expensiveLimiter))
+	streamInterceptors = append(streamInterceptors, grpcserver.StreamRateLimitInterceptor(limiter, expensiveLimiter))
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+	notionpb.RegisterTestCaseServiceServer(grpcServer, grpcserver.NewServer(projects))
+	reflection.Register(grpcServer)
+	go func() {
+		slog.Info("gRPC server starting", slog.String("port", grpcPort))
+		if err := grpcServer.Serve(listener); err != nil {
+			fatal("gRPC server stopped", err)
+		}
+	}()
+
 	// Get port from environment or use default
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
 
-	log.Printf("Server starting on port %s", port)
-	log.Fatal(r.Run(":" + port))
+	server := &http.Server{Addr: ":" + port, Handler: r}
+	go func() {
+		slog.Info("Server starting", slog.String("port", port))
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			fatal("Server stopped", err)
+		}
+	}()
+
+	// On SIGINT or SIGTERM, let running requests finish and flush batched spans
+	<-ctx.Done()
+
+	slog.Info("Server shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Failed to shut down server", slog.Any("error", err))
+	}
+	stopGRPC(shutdownCtx, grpcServer)
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Failed to flush traces", slog.Any("error", err))
+	}
+}
+
+// shutdownTimeout bounds how long shutdown waits for requests and span exports
+const shutdownTimeout = 10 * time.Second
+
+// stopGRPC stops the gRPC server gracefully, or closes its connections when
+// ctx ends first, e.g. while a client holds a stream open
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
+
+// fatal logs err and exits
+func fatal(message string, err error) {
+	slog.Error(message, slog.Any("error", err))
+	os.Exit(1)
 }
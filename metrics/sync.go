@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	syncLastSuccessDesc = prometheus.NewDesc(
+		"sync_last_success_timestamp_seconds",
+		"Unix time at which the last sync of a project finished.",
+		[]string{"project"}, nil,
+	)
+	syncLagDesc = prometheus.NewDesc(
+		"sync_lag_seconds",
+		"Seconds since the last sync of a project finished.",
+		[]string{"project"}, nil,
+	)
+)
+
+// syncCollector reports the sync times of each project when scraped, so that
+// the lag keeps growing while syncs fail
+type syncCollector struct {
+	lastSynced func() map[string]time.Time
+}
+
+// RegisterSync reports sync freshness from lastSynced, which returns the time
+// of the last finished sync per project
+func RegisterSync(lastSynced func() map[string]time.Time) {
+	prometheus.MustRegister(&syncCollector{lastSynced: lastSynced})
+}
+
+func (c *syncCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- syncLastSuccessDesc
+	ch <- syncLagDesc
+}
+
+func (c *syncCollector) Collect(ch chan<- prometheus.Metric) {
+	now := time.Now()
+	for project, synced := range c.lastSynced() {
+		ch <- prometheus.MustNewConstMetric(syncLastSuccessDesc, prometheus.GaugeValue, float64(synced.UnixNano())/1e9, project)
+		ch <- prometheus.MustNewConstMetric(syncLagDesc, prometheus.GaugeValue, now.Sub(synced).Seconds(), project)
+	}
+}
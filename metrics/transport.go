@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transport counts and times upstream calls per endpoint
+type Transport struct {
+	// Upstream names the service in the upstream label
+	Upstream string
+	// Base performs the calls; http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+
+	endpoint := Endpoint(req.URL.Path)
+	upstreamDuration.WithLabelValues(t.Upstream, req.Method, endpoint).Observe(time.Since(start).Seconds())
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			upstreamRateLimited.WithLabelValues(t.Upstream, endpoint).Inc()
+		}
+	}
+	upstreamRequests.WithLabelValues(t.Upstream, req.Method, endpoint, status).Inc()
+
+	return resp, err
+}
+
+// Endpoint replaces the Notion IDs in an upstream path with {id}, e.g.
+// /v1/blocks/1a2b…/children becomes /v1/blocks/{id}/children, so that every
+// page and block shares one series
+func Endpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if isID(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Helper functions
+
+// isID reports whether segment is a 32-digit hex ID, with or without dashes
+func isID(segment string) bool {
+	hex := strings.ReplaceAll(segment, "-", "")
+	if len(hex) != 32 {
+		return false
+	}
+	for _, r := range strings.ToLower(hex) {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// TestCaseVersion is a copy of a test case taken when a sync saw a new last edited time
+type TestCaseVersion struct {
+	Version        int                      `json:"version"`
+	LastEdited     time.Time                `json:"last_edited"`
+	LastEditedBy   string                   `json:"last_edited_by,omitempty"`
+	LastEditedByID string                   `json:"last_edited_by_id,omitempty"`
+	SyncedAt       time.Time                `json:"synced_at"`
+	TestCase       DetailedTestCaseResponse `json:"test_case"`
+	Blocks         []BlockResponse          `json:"blocks,omitempty"`
+}
+
+// VersionSummary describes a version without its content
+type VersionSummary struct {
+	Version      int       `json:"version"`
+	Title        string    `json:"title"`
+	Status       string    `json:"status"`
+	LastEdited   time.Time `json:"last_edited"`
+	LastEditedBy string    `json:"last_edited_by,omitempty"`
+	SyncedAt     time.Time `json:"synced_at"`
+}
+
+// HistoryResponse lists the versions of a test case, oldest first
+type HistoryResponse struct {
+	Project     string           `json:"project"`
+	TestCaseKey string           `json:"test_case_key"`
+	Versions    []VersionSummary `json:"versions"`
+}
+
+// VersionDiff describes what changed between two versions of a test case.
+// Each change names the latest version in the range that touched it.
+type VersionDiff struct {
+	Project     string               `json:"project"`
+	TestCaseKey string               `json:"test_case_key"`
+	From        VersionSummary       `json:"from"`
+	To          VersionSummary       `json:"to"`
+	Properties  []HistoryChange      `json:"properties"`
+	Steps       []HistoryStepChange  `json:"steps"`
+	Blocks      []HistoryBlockChange `json:"blocks"`
+}
+
+// Attribution is the version in which a change was seen and who made it
+type Attribution struct {
+	Version   int       `json:"version"`
+	ChangedBy string    `json:"changed_by,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+type HistoryChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+	Attribution
+}
+
+// HistoryStepChange describes an added, removed or modified step
+type HistoryStepChange struct {
+	Number int             `json:"number"`
+	Change string          `json:"change"`
+	Fields []HistoryChange `json:"fields,omitempty"`
+	Attribution
+}
+
+// HistoryBlockChange describes an added, removed or modified text block
+type HistoryBlockChange struct {
+	BlockID string `json:"block_id"`
+	Type    string `json:"type"`
+	Change  string `json:"change"`
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+	Attribution
+}
@@ -0,0 +1,42 @@
+package models
+
+// ImportResult represents the diff between an imported spreadsheet and Notion.
+// With DryRun set nothing was written; otherwise Created and Updated list the
+// changes that were applied successfully.
+type ImportResult struct {
+	DryRun    bool           `json:"dry_run"`
+	Created   []ImportChange `json:"created"`
+	Updated   []ImportChange `json:"updated"`
+	Unchanged []string       `json:"unchanged"`
+	Errors    []ImportError  `json:"errors,omitempty"`
+}
+
+// ImportChange describes a test case that is created or updated
+type ImportChange struct {
+	TestCaseKey string        `json:"test_case_key"`
+	PageID      string        `json:"page_id,omitempty"`
+	Title       string        `json:"title"`
+	Fields      []FieldChange `json:"fields,omitempty"`
+	Steps       []StepChange  `json:"steps,omitempty"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// StepChange describes an added, removed or modified step
+type StepChange struct {
+	Number int           `json:"number"`
+	Change string        `json:"change"`
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// ImportError reports a spreadsheet row or test case that could not be imported
+type ImportError struct {
+	Sheet       string `json:"sheet,omitempty"`
+	Row         int    `json:"row,omitempty"`
+	TestCaseKey string `json:"test_case_key,omitempty"`
+	Message     string `json:"message"`
+}
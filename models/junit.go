@@ -0,0 +1,74 @@
+package models
+
+import "encoding/xml"
+
+// JUnitTestSuites is the root element of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr,omitempty"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     float64          `xml:"time,attr,omitempty"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite is a testsuite element. Manual test cases record no duration,
+// so Time is only set for parsed reports and omitted when zero.
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      float64         `xml:"time,attr,omitempty"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr,omitempty"`
+	Timestamp string        `xml:"timestamp,attr,omitempty"`
+	Failure   *JUnitMessage `xml:"failure,omitempty"`
+	Error     *JUnitMessage `xml:"error,omitempty"`
+	Skipped   *JUnitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// JUnitMessage is the body of a failure, error or skipped element
+type JUnitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Content string `xml:",chardata"`
+}
+
+// JUnitIngestResult summarizes how a JUnit report was applied to Notion
+type JUnitIngestResult struct {
+	DryRun    bool           `json:"dry_run"`
+	Matched   []JUnitMatch   `json:"matched"`
+	Updated   []string       `json:"updated"`
+	Unchanged []string       `json:"unchanged"`
+	Unknown   []JUnitUnknown `json:"unknown"`
+	Errors    []ImportError  `json:"errors,omitempty"`
+}
+
+// JUnitMatch is a test case matched by one or more JUnit testcases
+type JUnitMatch struct {
+	TestCaseKey    string   `json:"test_case_key"`
+	PageID         string   `json:"page_id"`
+	JUnitTestCases []string `json:"junit_test_cases"`
+	Outcome        string   `json:"outcome"`
+	PreviousStatus string   `json:"previous_status"`
+	Status         string   `json:"status"`
+	TestDate       string   `json:"test_date"`
+}
+
+// JUnitUnknown is a JUnit testcase that carries no key or an unknown key
+type JUnitUnknown struct {
+	Name        string `json:"name"`
+	TestCaseKey string `json:"test_case_key,omitempty"`
+	Reason      string `json:"reason"`
+}
@@ -4,9 +4,11 @@ import "time"
 
 // NotionSearchRequest represents the search request payload
 type NotionSearchRequest struct {
-	Query  string             `json:"query"`
-	Filter NotionSearchFilter `json:"filter"`
-	Sort   NotionSearchSort   `json:"sort"`
+	Query       string             `json:"query"`
+	Filter      NotionSearchFilter `json:"filter"`
+	Sort        NotionSearchSort   `json:"sort"`
+	StartCursor string             `json:"start_cursor,omitempty"`
+	PageSize    int                `json:"page_size,omitempty"`
 }
 
 type NotionSearchFilter struct {
@@ -19,6 +21,13 @@ type NotionSearchSort struct {
 	Timestamp string `json:"timestamp"`
 }
 
+// NotionDatabaseQueryRequest represents the database query request payload
+type NotionDatabaseQueryRequest struct {
+	Sorts       []NotionSearchSort `json:"sorts,omitempty"`
+	StartCursor string             `json:"start_cursor,omitempty"`
+	PageSize    int                `json:"page_size,omitempty"`
+}
+
 // NotionSearchResponse represents the search response
 type NotionSearchResponse struct {
 	Object     string       `json:"object"`
@@ -49,6 +58,7 @@ type NotionPage struct {
 type NotionUser struct {
 	Object string `json:"object"`
 	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
 }
 
 type NotionParent struct {
@@ -110,7 +120,9 @@ type NotionBlock struct {
 	Table          *NotionTable      `json:"table,omitempty"`
 	TableRow       *NotionTableRow   `json:"table_row,omitempty"`
 	Paragraph      *NotionParagraph  `json:"paragraph,omitempty"`
+	Heading1       *NotionHeading    `json:"heading_1,omitempty"`
 	Heading2       *NotionHeading    `json:"heading_2,omitempty"`
+	Heading3       *NotionHeading    `json:"heading_3,omitempty"`
 }
 
 type NotionBlockParent struct {
@@ -142,22 +154,35 @@ type RichText struct {
 
 // TestCaseResponse represents our custom response for test cases
 type TestCaseResponse struct {
-	TestCaseKey string    `json:"test_case_key"`
-	PageID      string    `json:"page_id"`
-	Title       string    `json:"title"`
-	Status      string    `json:"status"`
-	TestDate    string    `json:"test_date"`
-	URL         string    `json:"url"`
-	LastEdited  time.Time `json:"last_edited"`
+	Project      string    `json:"project,omitempty"`
+	TestCaseKey  string    `json:"test_case_key"`
+	PageID       string    `json:"page_id"`
+	Title        string    `json:"title"`
+	Status       string    `json:"status"`
+	TestDate     string    `json:"test_date"`
+	Tags         []string  `json:"tags,omitempty"`
+	Assignees    []string  `json:"assignees,omitempty"`
+	URL          string    `json:"url"`
+	LastEdited   time.Time `json:"last_edited"`
+	LastEditedBy string    `json:"last_edited_by,omitempty"`
+}
+
+// TestCaseUpdate holds test case property values to write to Notion; nil fields are left unchanged
+type TestCaseUpdate struct {
+	Title    *string   `json:"title,omitempty"`
+	Status   *string   `json:"status,omitempty"`
+	TestDate *string   `json:"test_date,omitempty"`
+	Tags     *[]string `json:"tags,omitempty"`
 }
 
 // BlockResponse represents our custom response for blocks
 type BlockResponse struct {
-	BlockID     string     `json:"block_id"`
-	Type        string     `json:"type"`
-	HasChildren bool       `json:"has_children"`
-	Content     string     `json:"content,omitempty"`
-	TableInfo   *TableInfo `json:"table_info,omitempty"`
+	BlockID     string          `json:"block_id"`
+	Type        string          `json:"type"`
+	HasChildren bool            `json:"has_children"`
+	Content     string          `json:"content,omitempty"`
+	TableInfo   *TableInfo      `json:"table_info,omitempty"`
+	Children    []BlockResponse `json:"children,omitempty"`
 }
 
 type TableInfo struct {
@@ -169,27 +194,44 @@ type TableInfo struct {
 
 // TableRow represents a row in a table
 type TableRow struct {
-	Cells []string `json:"cells"`
+	BlockID string   `json:"block_id,omitempty"`
+	Cells   []string `json:"cells"`
 }
 
 // Detailed test case response with table data
 type DetailedTestCaseResponse struct {
-	TestCaseKey string          `json:"test_case_key"`
-	PageID      string          `json:"page_id"`
-	Title       string          `json:"title"`
-	Status      string          `json:"status"`
-	TestDate    string          `json:"test_date"`
-	URL         string          `json:"url"`
-	LastEdited  time.Time       `json:"last_edited"`
-	Tables      []TableWithData `json:"tables,omitempty"`
+	Project      string          `json:"project,omitempty"`
+	TestCaseKey  string          `json:"test_case_key"`
+	PageID       string          `json:"page_id"`
+	Title        string          `json:"title"`
+	Status       string          `json:"status"`
+	TestDate     string          `json:"test_date"`
+	Tags         []string        `json:"tags,omitempty"`
+	Assignees    []string        `json:"assignees,omitempty"`
+	URL          string          `json:"url"`
+	LastEdited   time.Time       `json:"last_edited"`
+	LastEditedBy string          `json:"last_edited_by,omitempty"`
+	Tables       []TableWithData `json:"tables,omitempty"`
+	Errors       []TestCaseError `json:"errors,omitempty"`
+}
+
+// TestCaseError is a part of a test case that could not be read from Notion.
+// A test case with errors may have fewer tables than its page.
+type TestCaseError struct {
+	TestCaseKey string `json:"test_case_key"`
+	PageID      string `json:"page_id"`
+	BlockID     string `json:"block_id,omitempty"`
+	Message     string `json:"message"`
 }
 
 type TableWithData struct {
-	BlockID         string     `json:"block_id"`
-	TableWidth      int        `json:"table_width"`
-	HasColumnHeader bool       `json:"has_column_header"`
-	HasRowHeader    bool       `json:"has_row_header"`
-	Rows            []TableRow `json:"rows"`
+	BlockID         string        `json:"block_id"`
+	TableWidth      int           `json:"table_width"`
+	HasColumnHeader bool          `json:"has_column_header"`
+	HasRowHeader    bool          `json:"has_row_header"`
+	Rows            []TableRow    `json:"rows"`
+	Steps           []TestStep    `json:"steps,omitempty"`
+	Warnings        []StepWarning `json:"warnings,omitempty"`
 }
 
 // Table row block structure from Notion API
@@ -0,0 +1,8 @@
+package models
+
+// ProjectInfo represents a project in API responses; tokens are never exposed
+type ProjectInfo struct {
+	Name       string `json:"name"`
+	DatabaseID string `json:"database_id,omitempty"`
+	Default    bool   `json:"default"`
+}
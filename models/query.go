@@ -0,0 +1,27 @@
+package models
+
+// TestCaseQuery filters, sorts and pages a test case listing. Zero values match
+// every test case; without a limit the whole listing is returned.
+type TestCaseQuery struct {
+	Statuses     []string
+	TestDateFrom string
+	TestDateTo   string
+	KeyPrefix    string
+	KeyFrom      string
+	KeyTo        string
+	Title        string
+	Tags         []string
+	Assignee     string
+	Sort         string
+	Descending   bool
+	Cursor       string
+	Limit        int
+}
+
+// Pagination describes one page of a listing. NextCursor fetches the page after it.
+type Pagination struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
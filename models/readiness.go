@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Readiness of the service
+const (
+	ReadinessReady    = "ready"
+	ReadinessNotReady = "not_ready"
+)
+
+// Results of a component check. Only fail makes the service not ready;
+// warn flags a problem that does not keep requests from being served.
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+	CheckSkip = "skip"
+)
+
+// Components checked per project
+const (
+	ComponentNotionAPIKey   = "notion_api_key"
+	ComponentNotionAPI      = "notion_api"
+	ComponentNotionDatabase = "notion_database"
+	ComponentSync           = "sync"
+)
+
+// ComponentCheck is the result of checking one component of a project
+type ComponentCheck struct {
+	Name      string `json:"name"`
+	Project   string `json:"project"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+	LatencyMS int64  `json:"latency_ms,omitempty"`
+}
+
+// ReadinessReport is ready when no component check failed
+type ReadinessReport struct {
+	Status     string           `json:"status"`
+	CheckedAt  time.Time        `json:"checked_at"`
+	Components []ComponentCheck `json:"components"`
+}
@@ -0,0 +1,38 @@
+package models
+
+// Search hit fields
+const (
+	SearchFieldTitle     = "title"
+	SearchFieldBlock     = "block"
+	SearchFieldTableCell = "table_cell"
+)
+
+// SearchResponse lists the test cases matching a full-text query, best match first
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Total   int            `json:"total"`
+	Results []SearchResult `json:"results"`
+}
+
+// SearchResult is a test case with the places where the query was found
+type SearchResult struct {
+	Project     string      `json:"project,omitempty"`
+	TestCaseKey string      `json:"test_case_key"`
+	PageID      string      `json:"page_id"`
+	Title       string      `json:"title"`
+	URL         string      `json:"url"`
+	Score       int         `json:"score"`
+	Hits        []SearchHit `json:"hits"`
+}
+
+// SearchHit is a title, text block or table cell containing a query term.
+// Matches are wrapped in <mark> tags in the snippet.
+type SearchHit struct {
+	Field      string `json:"field"`
+	BlockID    string `json:"block_id,omitempty"`
+	BlockType  string `json:"block_type,omitempty"`
+	RowBlockID string `json:"row_block_id,omitempty"`
+	StepNumber int    `json:"step_number,omitempty"`
+	Column     string `json:"column,omitempty"`
+	Snippet    string `json:"snippet"`
+}
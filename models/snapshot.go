@@ -0,0 +1,23 @@
+package models
+
+// StatusSnapshot records the status of every test case of a project on one day
+type StatusSnapshot struct {
+	Date     string            `json:"date"`
+	Project  string            `json:"project"`
+	TakenAt  string            `json:"taken_at"`
+	Statuses map[string]string `json:"statuses"`
+}
+
+// TrendPoint is the progress of a project on one snapshot date
+type TrendPoint struct {
+	Date string `json:"date"`
+	ProgressSummary
+}
+
+// TrendResponse represents a time series of status counts
+type TrendResponse struct {
+	Project string       `json:"project"`
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	Points  []TrendPoint `json:"points"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// StatsResponse represents aggregate test progress statistics
+type StatsResponse struct {
+	Progress   ProgressSummary `json:"progress"`
+	DateBucket string          `json:"date_bucket"`
+	ByTestDate map[string]int  `json:"by_test_date"`
+	ByTag      map[string]int  `json:"by_tag"`
+	ByAssignee map[string]int  `json:"by_assignee"`
+	Steps      StepStats       `json:"steps"`
+}
+
+// StepStats represents step-level outcome totals computed from step tables
+type StepStats struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Blocked int `json:"blocked"`
+	Skipped int `json:"skipped"`
+	NotRun  int `json:"not_run"`
+}
@@ -0,0 +1,20 @@
+package models
+
+// TestStep represents a typed row of a step table
+type TestStep struct {
+	BlockID        string            `json:"block_id,omitempty"`
+	Number         int               `json:"number"`
+	Action         string            `json:"action"`
+	ExpectedResult string            `json:"expected_result"`
+	ActualResult   string            `json:"actual_result"`
+	Status         string            `json:"status"`
+	Screenshot     string            `json:"screenshot"`
+	Extra          map[string]string `json:"extra,omitempty"`
+}
+
+// StepWarning reports a table row that could not be mapped to a TestStep.
+// Row is the zero-based index into TableWithData.Rows.
+type StepWarning struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
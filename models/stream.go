@@ -0,0 +1,31 @@
+package models
+
+// Events of a detailed test case stream
+const (
+	StreamEventTestCase = "test_case"
+	StreamEventProgress = "progress"
+	StreamEventSummary  = "summary"
+	StreamEventError    = "error"
+)
+
+// StreamProgress is sent after each test case of a stream
+type StreamProgress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+// StreamSummary ends a stream. Failed counts the test cases with at least one error.
+type StreamSummary struct {
+	Total      int             `json:"total"`
+	Succeeded  int             `json:"succeeded"`
+	Failed     int             `json:"failed"`
+	Errors     []TestCaseError `json:"errors"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+// StreamEvent is one line of an NDJSON stream; Server-Sent Events carry the
+// event name in the event field and the data alone
+type StreamEvent struct {
+	Event string `json:"event"`
+	Data  any    `json:"data"`
+}
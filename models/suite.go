@@ -0,0 +1,87 @@
+package models
+
+// Status outcomes used when aggregating Notion statuses into progress figures
+const (
+	OutcomePassed     = "passed"
+	OutcomeFailed     = "failed"
+	OutcomeBlocked    = "blocked"
+	OutcomeSkipped    = "skipped"
+	OutcomeInProgress = "in_progress"
+	OutcomeNotRun     = "not_run"
+)
+
+// SuitesFile represents the suites and plans definition file
+type SuitesFile struct {
+	Suites []TestSuite `json:"suites"`
+	Plans  []TestPlan  `json:"plans"`
+}
+
+// TestSuite groups test cases by explicit keys, key ranges or property filters.
+// A test case belongs to the suite when it matches any of Keys or KeyRanges,
+// or when Filter is set and all of its conditions match.
+type TestSuite struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Keys        []string     `json:"keys,omitempty"`
+	KeyRanges   []KeyRange   `json:"key_ranges,omitempty"`
+	Filter      *SuiteFilter `json:"filter,omitempty"`
+}
+
+// KeyRange is an inclusive range of test case keys (e.g., "01001" to "01099")
+type KeyRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type SuiteFilter struct {
+	Statuses []string `json:"statuses,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// TestPlan references the suites that make up a release
+type TestPlan struct {
+	Name        string   `json:"name"`
+	Release     string   `json:"release,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Suites      []string `json:"suites"`
+}
+
+// SuiteTestCasesResponse represents a suite with its resolved test cases
+type SuiteTestCasesResponse struct {
+	Suite     TestSuite          `json:"suite"`
+	TestCases []TestCaseResponse `json:"test_cases"`
+}
+
+// PlanTestCasesResponse represents a plan with its resolved test cases.
+// Test cases that belong to several suites of the plan are listed once.
+type PlanTestCasesResponse struct {
+	Plan      TestPlan           `json:"plan"`
+	TestCases []TestCaseResponse `json:"test_cases"`
+}
+
+// ProgressSummary represents aggregate execution progress over a set of test cases
+type ProgressSummary struct {
+	Total      int            `json:"total"`
+	Executed   int            `json:"executed"`
+	Remaining  int            `json:"remaining"`
+	Passed     int            `json:"passed"`
+	Failed     int            `json:"failed"`
+	Blocked    int            `json:"blocked"`
+	Skipped    int            `json:"skipped"`
+	InProgress int            `json:"in_progress"`
+	NotRun     int            `json:"not_run"`
+	PassRate   float64        `json:"pass_rate"`
+	ByStatus   map[string]int `json:"by_status"`
+}
+
+// PlanProgressResponse represents the progress of a plan and each of its suites
+type PlanProgressResponse struct {
+	Plan     TestPlan        `json:"plan"`
+	Progress ProgressSummary `json:"progress"`
+	Suites   []SuiteProgress `json:"suites"`
+}
+
+type SuiteProgress struct {
+	Suite    string          `json:"suite"`
+	Progress ProgressSummary `json:"progress"`
+}
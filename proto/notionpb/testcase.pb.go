@@ -0,0 +1,1680 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: testcase.proto
+
+package notionpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TestCase struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	TestCaseKey   string                 `protobuf:"bytes,2,opt,name=test_case_key,json=testCaseKey,proto3" json:"test_case_key,omitempty"`
+	PageId        string                 `protobuf:"bytes,3,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	TestDate      string                 `protobuf:"bytes,6,opt,name=test_date,json=testDate,proto3" json:"test_date,omitempty"`
+	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Assignees     []string               `protobuf:"bytes,8,rep,name=assignees,proto3" json:"assignees,omitempty"`
+	Url           string                 `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
+	LastEdited    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_edited,json=lastEdited,proto3" json:"last_edited,omitempty"`
+	LastEditedBy  string                 `protobuf:"bytes,11,opt,name=last_edited_by,json=lastEditedBy,proto3" json:"last_edited_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestCase) Reset() {
+	*x = TestCase{}
+	mi := &file_testcase_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCase) ProtoMessage() {}
+
+func (x *TestCase) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCase.ProtoReflect.Descriptor instead.
+func (*TestCase) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TestCase) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *TestCase) GetTestCaseKey() string {
+	if x != nil {
+		return x.TestCaseKey
+	}
+	return ""
+}
+
+func (x *TestCase) GetPageId() string {
+	if x != nil {
+		return x.PageId
+	}
+	return ""
+}
+
+func (x *TestCase) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TestCase) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TestCase) GetTestDate() string {
+	if x != nil {
+		return x.TestDate
+	}
+	return ""
+}
+
+func (x *TestCase) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TestCase) GetAssignees() []string {
+	if x != nil {
+		return x.Assignees
+	}
+	return nil
+}
+
+func (x *TestCase) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *TestCase) GetLastEdited() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastEdited
+	}
+	return nil
+}
+
+func (x *TestCase) GetLastEditedBy() string {
+	if x != nil {
+		return x.LastEditedBy
+	}
+	return ""
+}
+
+type DetailedTestCase struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TestCase      *TestCase              `protobuf:"bytes,1,opt,name=test_case,json=testCase,proto3" json:"test_case,omitempty"`
+	Tables        []*TableWithData       `protobuf:"bytes,2,rep,name=tables,proto3" json:"tables,omitempty"`
+	Errors        []*TestCaseError       `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetailedTestCase) Reset() {
+	*x = DetailedTestCase{}
+	mi := &file_testcase_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetailedTestCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedTestCase) ProtoMessage() {}
+
+func (x *DetailedTestCase) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedTestCase.ProtoReflect.Descriptor instead.
+func (*DetailedTestCase) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DetailedTestCase) GetTestCase() *TestCase {
+	if x != nil {
+		return x.TestCase
+	}
+	return nil
+}
+
+func (x *DetailedTestCase) GetTables() []*TableWithData {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *DetailedTestCase) GetErrors() []*TestCaseError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type TestCaseError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TestCaseKey   string                 `protobuf:"bytes,1,opt,name=test_case_key,json=testCaseKey,proto3" json:"test_case_key,omitempty"`
+	PageId        string                 `protobuf:"bytes,2,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	BlockId       string                 `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestCaseError) Reset() {
+	*x = TestCaseError{}
+	mi := &file_testcase_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestCaseError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCaseError) ProtoMessage() {}
+
+func (x *TestCaseError) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCaseError.ProtoReflect.Descriptor instead.
+func (*TestCaseError) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TestCaseError) GetTestCaseKey() string {
+	if x != nil {
+		return x.TestCaseKey
+	}
+	return ""
+}
+
+func (x *TestCaseError) GetPageId() string {
+	if x != nil {
+		return x.PageId
+	}
+	return ""
+}
+
+func (x *TestCaseError) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *TestCaseError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Block struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockId       string                 `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	HasChildren   bool                   `protobuf:"varint,3,opt,name=has_children,json=hasChildren,proto3" json:"has_children,omitempty"`
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	TableInfo     *TableInfo             `protobuf:"bytes,5,opt,name=table_info,json=tableInfo,proto3" json:"table_info,omitempty"`
+	Children      []*Block               `protobuf:"bytes,6,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_testcase_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Block) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *Block) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Block) GetHasChildren() bool {
+	if x != nil {
+		return x.HasChildren
+	}
+	return false
+}
+
+func (x *Block) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Block) GetTableInfo() *TableInfo {
+	if x != nil {
+		return x.TableInfo
+	}
+	return nil
+}
+
+func (x *Block) GetChildren() []*Block {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type TableInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TableWidth      int32                  `protobuf:"varint,1,opt,name=table_width,json=tableWidth,proto3" json:"table_width,omitempty"`
+	HasColumnHeader bool                   `protobuf:"varint,2,opt,name=has_column_header,json=hasColumnHeader,proto3" json:"has_column_header,omitempty"`
+	HasRowHeader    bool                   `protobuf:"varint,3,opt,name=has_row_header,json=hasRowHeader,proto3" json:"has_row_header,omitempty"`
+	Rows            []*TableRow            `protobuf:"bytes,4,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TableInfo) Reset() {
+	*x = TableInfo{}
+	mi := &file_testcase_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableInfo) ProtoMessage() {}
+
+func (x *TableInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableInfo.ProtoReflect.Descriptor instead.
+func (*TableInfo) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TableInfo) GetTableWidth() int32 {
+	if x != nil {
+		return x.TableWidth
+	}
+	return 0
+}
+
+func (x *TableInfo) GetHasColumnHeader() bool {
+	if x != nil {
+		return x.HasColumnHeader
+	}
+	return false
+}
+
+func (x *TableInfo) GetHasRowHeader() bool {
+	if x != nil {
+		return x.HasRowHeader
+	}
+	return false
+}
+
+func (x *TableInfo) GetRows() []*TableRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type TableRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockId       string                 `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Cells         []string               `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableRow) Reset() {
+	*x = TableRow{}
+	mi := &file_testcase_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableRow) ProtoMessage() {}
+
+func (x *TableRow) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableRow.ProtoReflect.Descriptor instead.
+func (*TableRow) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TableRow) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *TableRow) GetCells() []string {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+type TableWithData struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	BlockId         string                 `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	TableWidth      int32                  `protobuf:"varint,2,opt,name=table_width,json=tableWidth,proto3" json:"table_width,omitempty"`
+	HasColumnHeader bool                   `protobuf:"varint,3,opt,name=has_column_header,json=hasColumnHeader,proto3" json:"has_column_header,omitempty"`
+	HasRowHeader    bool                   `protobuf:"varint,4,opt,name=has_row_header,json=hasRowHeader,proto3" json:"has_row_header,omitempty"`
+	Rows            []*TableRow            `protobuf:"bytes,5,rep,name=rows,proto3" json:"rows,omitempty"`
+	Steps           []*TestStep            `protobuf:"bytes,6,rep,name=steps,proto3" json:"steps,omitempty"`
+	Warnings        []*StepWarning         `protobuf:"bytes,7,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TableWithData) Reset() {
+	*x = TableWithData{}
+	mi := &file_testcase_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableWithData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableWithData) ProtoMessage() {}
+
+func (x *TableWithData) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableWithData.ProtoReflect.Descriptor instead.
+func (*TableWithData) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TableWithData) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *TableWithData) GetTableWidth() int32 {
+	if x != nil {
+		return x.TableWidth
+	}
+	return 0
+}
+
+func (x *TableWithData) GetHasColumnHeader() bool {
+	if x != nil {
+		return x.HasColumnHeader
+	}
+	return false
+}
+
+func (x *TableWithData) GetHasRowHeader() bool {
+	if x != nil {
+		return x.HasRowHeader
+	}
+	return false
+}
+
+func (x *TableWithData) GetRows() []*TableRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *TableWithData) GetSteps() []*TestStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *TableWithData) GetWarnings() []*StepWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type TestStep struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BlockId        string                 `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Number         int32                  `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Action         string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	ExpectedResult string                 `protobuf:"bytes,4,opt,name=expected_result,json=expectedResult,proto3" json:"expected_result,omitempty"`
+	ActualResult   string                 `protobuf:"bytes,5,opt,name=actual_result,json=actualResult,proto3" json:"actual_result,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Screenshot     string                 `protobuf:"bytes,7,opt,name=screenshot,proto3" json:"screenshot,omitempty"`
+	Extra          map[string]string      `protobuf:"bytes,8,rep,name=extra,proto3" json:"extra,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TestStep) Reset() {
+	*x = TestStep{}
+	mi := &file_testcase_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestStep) ProtoMessage() {}
+
+func (x *TestStep) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestStep.ProtoReflect.Descriptor instead.
+func (*TestStep) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TestStep) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *TestStep) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *TestStep) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TestStep) GetExpectedResult() string {
+	if x != nil {
+		return x.ExpectedResult
+	}
+	return ""
+}
+
+func (x *TestStep) GetActualResult() string {
+	if x != nil {
+		return x.ActualResult
+	}
+	return ""
+}
+
+func (x *TestStep) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TestStep) GetScreenshot() string {
+	if x != nil {
+		return x.Screenshot
+	}
+	return ""
+}
+
+func (x *TestStep) GetExtra() map[string]string {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type StepWarning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepWarning) Reset() {
+	*x = StepWarning{}
+	mi := &file_testcase_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepWarning) ProtoMessage() {}
+
+func (x *StepWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepWarning.ProtoReflect.Descriptor instead.
+func (*StepWarning) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StepWarning) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *StepWarning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListTestCasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Statuses      []string               `protobuf:"bytes,2,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	TestDateFrom  string                 `protobuf:"bytes,3,opt,name=test_date_from,json=testDateFrom,proto3" json:"test_date_from,omitempty"`
+	TestDateTo    string                 `protobuf:"bytes,4,opt,name=test_date_to,json=testDateTo,proto3" json:"test_date_to,omitempty"`
+	KeyPrefix     string                 `protobuf:"bytes,5,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	KeyFrom       string                 `protobuf:"bytes,6,opt,name=key_from,json=keyFrom,proto3" json:"key_from,omitempty"`
+	KeyTo         string                 `protobuf:"bytes,7,opt,name=key_to,json=keyTo,proto3" json:"key_to,omitempty"`
+	Title         string                 `protobuf:"bytes,8,opt,name=title,proto3" json:"title,omitempty"`
+	Tags          []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	Assignee      string                 `protobuf:"bytes,10,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Sort          string                 `protobuf:"bytes,11,opt,name=sort,proto3" json:"sort,omitempty"`
+	Descending    bool                   `protobuf:"varint,12,opt,name=descending,proto3" json:"descending,omitempty"`
+	Limit         int32                  `protobuf:"varint,13,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,14,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTestCasesRequest) Reset() {
+	*x = ListTestCasesRequest{}
+	mi := &file_testcase_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTestCasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTestCasesRequest) ProtoMessage() {}
+
+func (x *ListTestCasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTestCasesRequest.ProtoReflect.Descriptor instead.
+func (*ListTestCasesRequest) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListTestCasesRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *ListTestCasesRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListTestCasesRequest) GetTestDateFrom() string {
+	if x != nil {
+		return x.TestDateFrom
+	}
+	return ""
+}
+
+func (x *ListTestCasesRequest) GetTestDateTo() string {
+	if x != nil {
+		return x.TestDateTo
+	}
+	return ""
+}
+
+func (x *ListTestCasesRequest) GetKeyPrefix() string {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return ""
+}
+
+func (x *ListTestCasesRequest) GetKeyFrom() string {
+	if x != nil {
+		return x.KeyFrom
+	}
+	return ""
+}
+
+func (x *ListTestCasesRequest) GetKeyTo() string {
+	if x != nil {
+		return x.KeyTo
+	}
+	return ""
+}
+
+func (x *ListTestCasesRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ListTestCasesRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListTestCasesRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *ListTestCasesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListTestCasesRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListTestCasesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTestCasesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListTestCasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TestCases     []*TestCase            `protobuf:"bytes,1,rep,name=test_cases,json=testCases,proto3" json:"test_cases,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTestCasesResponse) Reset() {
+	*x = ListTestCasesResponse{}
+	mi := &file_testcase_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTestCasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTestCasesResponse) ProtoMessage() {}
+
+func (x *ListTestCasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTestCasesResponse.ProtoReflect.Descriptor instead.
+func (*ListTestCasesResponse) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListTestCasesResponse) GetTestCases() []*TestCase {
+	if x != nil {
+		return x.TestCases
+	}
+	return nil
+}
+
+func (x *ListTestCasesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListTestCasesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListTestCasesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetTestCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	TestCaseKey   string                 `protobuf:"bytes,2,opt,name=test_case_key,json=testCaseKey,proto3" json:"test_case_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTestCaseRequest) Reset() {
+	*x = GetTestCaseRequest{}
+	mi := &file_testcase_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTestCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTestCaseRequest) ProtoMessage() {}
+
+func (x *GetTestCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTestCaseRequest.ProtoReflect.Descriptor instead.
+func (*GetTestCaseRequest) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTestCaseRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *GetTestCaseRequest) GetTestCaseKey() string {
+	if x != nil {
+		return x.TestCaseKey
+	}
+	return ""
+}
+
+type GetTestCaseBlocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	TestCaseKey   string                 `protobuf:"bytes,2,opt,name=test_case_key,json=testCaseKey,proto3" json:"test_case_key,omitempty"`
+	TablesOnly    bool                   `protobuf:"varint,3,opt,name=tables_only,json=tablesOnly,proto3" json:"tables_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTestCaseBlocksRequest) Reset() {
+	*x = GetTestCaseBlocksRequest{}
+	mi := &file_testcase_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTestCaseBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTestCaseBlocksRequest) ProtoMessage() {}
+
+func (x *GetTestCaseBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTestCaseBlocksRequest.ProtoReflect.Descriptor instead.
+func (*GetTestCaseBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTestCaseBlocksRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *GetTestCaseBlocksRequest) GetTestCaseKey() string {
+	if x != nil {
+		return x.TestCaseKey
+	}
+	return ""
+}
+
+func (x *GetTestCaseBlocksRequest) GetTablesOnly() bool {
+	if x != nil {
+		return x.TablesOnly
+	}
+	return false
+}
+
+type GetTestCaseBlocksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TestCase      *TestCase              `protobuf:"bytes,1,opt,name=test_case,json=testCase,proto3" json:"test_case,omitempty"`
+	Blocks        []*Block               `protobuf:"bytes,2,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTestCaseBlocksResponse) Reset() {
+	*x = GetTestCaseBlocksResponse{}
+	mi := &file_testcase_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTestCaseBlocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTestCaseBlocksResponse) ProtoMessage() {}
+
+func (x *GetTestCaseBlocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTestCaseBlocksResponse.ProtoReflect.Descriptor instead.
+func (*GetTestCaseBlocksResponse) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTestCaseBlocksResponse) GetTestCase() *TestCase {
+	if x != nil {
+		return x.TestCase
+	}
+	return nil
+}
+
+func (x *GetTestCaseBlocksResponse) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type GetBlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	BlockId       string                 `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRequest) Reset() {
+	*x = GetBlockRequest{}
+	mi := &file_testcase_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRequest) ProtoMessage() {}
+
+func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetBlockRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *GetBlockRequest) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+type Tags struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tags) Reset() {
+	*x = Tags{}
+	mi := &file_testcase_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tags) ProtoMessage() {}
+
+func (x *Tags) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tags.ProtoReflect.Descriptor instead.
+func (*Tags) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Tags) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type UpdateTestCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	TestCaseKey   string                 `protobuf:"bytes,2,opt,name=test_case_key,json=testCaseKey,proto3" json:"test_case_key,omitempty"`
+	Title         *string                `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Status        *string                `protobuf:"bytes,4,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	TestDate      *string                `protobuf:"bytes,5,opt,name=test_date,json=testDate,proto3,oneof" json:"test_date,omitempty"`
+	Tags          *Tags                  `protobuf:"bytes,6,opt,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTestCaseRequest) Reset() {
+	*x = UpdateTestCaseRequest{}
+	mi := &file_testcase_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTestCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTestCaseRequest) ProtoMessage() {}
+
+func (x *UpdateTestCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTestCaseRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTestCaseRequest) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateTestCaseRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *UpdateTestCaseRequest) GetTestCaseKey() string {
+	if x != nil {
+		return x.TestCaseKey
+	}
+	return ""
+}
+
+func (x *UpdateTestCaseRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTestCaseRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *UpdateTestCaseRequest) GetTestDate() string {
+	if x != nil && x.TestDate != nil {
+		return *x.TestDate
+	}
+	return ""
+}
+
+func (x *UpdateTestCaseRequest) GetTags() *Tags {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type CreateTestCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	TestDate      string                 `protobuf:"bytes,4,opt,name=test_date,json=testDate,proto3" json:"test_date,omitempty"`
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Steps         []*TestStep            `protobuf:"bytes,6,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTestCaseRequest) Reset() {
+	*x = CreateTestCaseRequest{}
+	mi := &file_testcase_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTestCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTestCaseRequest) ProtoMessage() {}
+
+func (x *CreateTestCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTestCaseRequest.ProtoReflect.Descriptor instead.
+func (*CreateTestCaseRequest) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateTestCaseRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *CreateTestCaseRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTestCaseRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateTestCaseRequest) GetTestDate() string {
+	if x != nil {
+		return x.TestDate
+	}
+	return ""
+}
+
+func (x *CreateTestCaseRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateTestCaseRequest) GetSteps() []*TestStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type UpdateTableRowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	BlockId       string                 `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Cells         []string               `protobuf:"bytes,3,rep,name=cells,proto3" json:"cells,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTableRowRequest) Reset() {
+	*x = UpdateTableRowRequest{}
+	mi := &file_testcase_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTableRowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTableRowRequest) ProtoMessage() {}
+
+func (x *UpdateTableRowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_testcase_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTableRowRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTableRowRequest) Descriptor() ([]byte, []int) {
+	return file_testcase_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateTableRowRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *UpdateTableRowRequest) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *UpdateTableRowRequest) GetCells() []string {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+var File_testcase_proto protoreflect.FileDescriptor
+
+const file_testcase_proto_rawDesc = "" +
+	"\n" +
+	"\x0etestcase.proto\x12\fnotionapi.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd3\x02\n" +
+	"\bTestCase\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\"\n" +
+	"\rtest_case_key\x18\x02 \x01(\tR\vtestCaseKey\x12\x17\n" +
+	"\apage_id\x18\x03 \x01(\tR\x06pageId\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1b\n" +
+	"\ttest_date\x18\x06 \x01(\tR\btestDate\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x1c\n" +
+	"\tassignees\x18\b \x03(\tR\tassignees\x12\x10\n" +
+	"\x03url\x18\t \x01(\tR\x03url\x12;\n" +
+	"\vlast_edited\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastEdited\x12$\n" +
+	"\x0elast_edited_by\x18\v \x01(\tR\flastEditedBy\"\xb1\x01\n" +
+	"\x10DetailedTestCase\x123\n" +
+	"\ttest_case\x18\x01 \x01(\v2\x16.notionapi.v1.TestCaseR\btestCase\x123\n" +
+	"\x06tables\x18\x02 \x03(\v2\x1b.notionapi.v1.TableWithDataR\x06tables\x123\n" +
+	"\x06errors\x18\x03 \x03(\v2\x1b.notionapi.v1.TestCaseErrorR\x06errors\"\x81\x01\n" +
+	"\rTestCaseError\x12\"\n" +
+	"\rtest_case_key\x18\x01 \x01(\tR\vtestCaseKey\x12\x17\n" +
+	"\apage_id\x18\x02 \x01(\tR\x06pageId\x12\x19\n" +
+	"\bblock_id\x18\x03 \x01(\tR\ablockId\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\xdc\x01\n" +
+	"\x05Block\x12\x19\n" +
+	"\bblock_id\x18\x01 \x01(\tR\ablockId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12!\n" +
+	"\fhas_children\x18\x03 \x01(\bR\vhasChildren\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x126\n" +
+	"\n" +
+	"table_info\x18\x05 \x01(\v2\x17.notionapi.v1.TableInfoR\ttableInfo\x12/\n" +
+	"\bchildren\x18\x06 \x03(\v2\x13.notionapi.v1.BlockR\bchildren\"\xaa\x01\n" +
+	"\tTableInfo\x12\x1f\n" +
+	"\vtable_width\x18\x01 \x01(\x05R\n" +
+	"tableWidth\x12*\n" +
+	"\x11has_column_header\x18\x02 \x01(\bR\x0fhasColumnHeader\x12$\n" +
+	"\x0ehas_row_header\x18\x03 \x01(\bR\fhasRowHeader\x12*\n" +
+	"\x04rows\x18\x04 \x03(\v2\x16.notionapi.v1.TableRowR\x04rows\";\n" +
+	"\bTableRow\x12\x19\n" +
+	"\bblock_id\x18\x01 \x01(\tR\ablockId\x12\x14\n" +
+	"\x05cells\x18\x02 \x03(\tR\x05cells\"\xae\x02\n" +
+	"\rTableWithData\x12\x19\n" +
+	"\bblock_id\x18\x01 \x01(\tR\ablockId\x12\x1f\n" +
+	"\vtable_width\x18\x02 \x01(\x05R\n" +
+	"tableWidth\x12*\n" +
+	"\x11has_column_header\x18\x03 \x01(\bR\x0fhasColumnHeader\x12$\n" +
+	"\x0ehas_row_header\x18\x04 \x01(\bR\fhasRowHeader\x12*\n" +
+	"\x04rows\x18\x05 \x03(\v2\x16.notionapi.v1.TableRowR\x04rows\x12,\n" +
+	"\x05steps\x18\x06 \x03(\v2\x16.notionapi.v1.TestStepR\x05steps\x125\n" +
+	"\bwarnings\x18\a \x03(\v2\x19.notionapi.v1.StepWarningR\bwarnings\"\xce\x02\n" +
+	"\bTestStep\x12\x19\n" +
+	"\bblock_id\x18\x01 \x01(\tR\ablockId\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\x05R\x06number\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12'\n" +
+	"\x0fexpected_result\x18\x04 \x01(\tR\x0eexpectedResult\x12#\n" +
+	"\ractual_result\x18\x05 \x01(\tR\factualResult\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"screenshot\x18\a \x01(\tR\n" +
+	"screenshot\x127\n" +
+	"\x05extra\x18\b \x03(\v2!.notionapi.v1.TestStep.ExtraEntryR\x05extra\x1a8\n" +
+	"\n" +
+	"ExtraEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"9\n" +
+	"\vStepWarning\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x8d\x03\n" +
+	"\x14ListTestCasesRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\x1a\n" +
+	"\bstatuses\x18\x02 \x03(\tR\bstatuses\x12$\n" +
+	"\x0etest_date_from\x18\x03 \x01(\tR\ftestDateFrom\x12 \n" +
+	"\ftest_date_to\x18\x04 \x01(\tR\n" +
+	"testDateTo\x12\x1d\n" +
+	"\n" +
+	"key_prefix\x18\x05 \x01(\tR\tkeyPrefix\x12\x19\n" +
+	"\bkey_from\x18\x06 \x01(\tR\akeyFrom\x12\x15\n" +
+	"\x06key_to\x18\a \x01(\tR\x05keyTo\x12\x14\n" +
+	"\x05title\x18\b \x01(\tR\x05title\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12\x1a\n" +
+	"\bassignee\x18\n" +
+	" \x01(\tR\bassignee\x12\x12\n" +
+	"\x04sort\x18\v \x01(\tR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\f \x01(\bR\n" +
+	"descending\x12\x14\n" +
+	"\x05limit\x18\r \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x0e \x01(\tR\x06cursor\"\xa0\x01\n" +
+	"\x15ListTestCasesResponse\x125\n" +
+	"\n" +
+	"test_cases\x18\x01 \x03(\v2\x16.notionapi.v1.TestCaseR\ttestCases\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\"R\n" +
+	"\x12GetTestCaseRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\"\n" +
+	"\rtest_case_key\x18\x02 \x01(\tR\vtestCaseKey\"y\n" +
+	"\x18GetTestCaseBlocksRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\"\n" +
+	"\rtest_case_key\x18\x02 \x01(\tR\vtestCaseKey\x12\x1f\n" +
+	"\vtables_only\x18\x03 \x01(\bR\n" +
+	"tablesOnly\"}\n" +
+	"\x19GetTestCaseBlocksResponse\x123\n" +
+	"\ttest_case\x18\x01 \x01(\v2\x16.notionapi.v1.TestCaseR\btestCase\x12+\n" +
+	"\x06blocks\x18\x02 \x03(\v2\x13.notionapi.v1.BlockR\x06blocks\"F\n" +
+	"\x0fGetBlockRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\x19\n" +
+	"\bblock_id\x18\x02 \x01(\tR\ablockId\"\x1e\n" +
+	"\x04Tags\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\xfa\x01\n" +
+	"\x15UpdateTestCaseRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\"\n" +
+	"\rtest_case_key\x18\x02 \x01(\tR\vtestCaseKey\x12\x19\n" +
+	"\x05title\x18\x03 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\x04 \x01(\tH\x01R\x06status\x88\x01\x01\x12 \n" +
+	"\ttest_date\x18\x05 \x01(\tH\x02R\btestDate\x88\x01\x01\x12&\n" +
+	"\x04tags\x18\x06 \x01(\v2\x12.notionapi.v1.TagsR\x04tagsB\b\n" +
+	"\x06_titleB\t\n" +
+	"\a_statusB\f\n" +
+	"\n" +
+	"_test_date\"\xbe\x01\n" +
+	"\x15CreateTestCaseRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1b\n" +
+	"\ttest_date\x18\x04 \x01(\tR\btestDate\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12,\n" +
+	"\x05steps\x18\x06 \x03(\v2\x16.notionapi.v1.TestStepR\x05steps\"b\n" +
+	"\x15UpdateTableRowRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\x19\n" +
+	"\bblock_id\x18\x02 \x01(\tR\ablockId\x12\x14\n" +
+	"\x05cells\x18\x03 \x03(\tR\x05cells2\xf0\x05\n" +
+	"\x0fTestCaseService\x12X\n" +
+	"\rListTestCases\x12\".notionapi.v1.ListTestCasesRequest\x1a#.notionapi.v1.ListTestCasesResponse\x12_\n" +
+	"\x17StreamDetailedTestCases\x12\".notionapi.v1.ListTestCasesRequest\x1a\x1e.notionapi.v1.DetailedTestCase0\x01\x12G\n" +
+	"\vGetTestCase\x12 .notionapi.v1.GetTestCaseRequest\x1a\x16.notionapi.v1.TestCase\x12d\n" +
+	"\x11GetTestCaseBlocks\x12&.notionapi.v1.GetTestCaseBlocksRequest\x1a'.notionapi.v1.GetTestCaseBlocksResponse\x12>\n" +
+	"\bGetBlock\x12\x1d.notionapi.v1.GetBlockRequest\x1a\x13.notionapi.v1.Block\x12F\n" +
+	"\bGetTable\x12\x1d.notionapi.v1.GetBlockRequest\x1a\x1b.notionapi.v1.TableWithData\x12M\n" +
+	"\x0eUpdateTestCase\x12#.notionapi.v1.UpdateTestCaseRequest\x1a\x16.notionapi.v1.TestCase\x12M\n" +
+	"\x0eCreateTestCase\x12#.notionapi.v1.CreateTestCaseRequest\x1a\x16.notionapi.v1.TestCase\x12M\n" +
+	"\x0eUpdateTableRow\x12#.notionapi.v1.UpdateTableRowRequest\x1a\x16.google.protobuf.EmptyB)Z'demo-notion-api/proto/notionpb;notionpbb\x06proto3"
+
+var (
+	file_testcase_proto_rawDescOnce sync.Once
+	file_testcase_proto_rawDescData []byte
+)
+
+func file_testcase_proto_rawDescGZIP() []byte {
+	file_testcase_proto_rawDescOnce.Do(func() {
+		file_testcase_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_testcase_proto_rawDesc), len(file_testcase_proto_rawDesc)))
+	})
+	return file_testcase_proto_rawDescData
+}
+
+var file_testcase_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_testcase_proto_goTypes = []any{
+	(*TestCase)(nil),                  // 0: notionapi.v1.TestCase
+	(*DetailedTestCase)(nil),          // 1: notionapi.v1.DetailedTestCase
+	(*TestCaseError)(nil),             // 2: notionapi.v1.TestCaseError
+	(*Block)(nil),                     // 3: notionapi.v1.Block
+	(*TableInfo)(nil),                 // 4: notionapi.v1.TableInfo
+	(*TableRow)(nil),                  // 5: notionapi.v1.TableRow
+	(*TableWithData)(nil),             // 6: notionapi.v1.TableWithData
+	(*TestStep)(nil),                  // 7: notionapi.v1.TestStep
+	(*StepWarning)(nil),               // 8: notionapi.v1.StepWarning
+	(*ListTestCasesRequest)(nil),      // 9: notionapi.v1.ListTestCasesRequest
+	(*ListTestCasesResponse)(nil),     // 10: notionapi.v1.ListTestCasesResponse
+	(*GetTestCaseRequest)(nil),        // 11: notionapi.v1.GetTestCaseRequest
+	(*GetTestCaseBlocksRequest)(nil),  // 12: notionapi.v1.GetTestCaseBlocksRequest
+	(*GetTestCaseBlocksResponse)(nil), // 13: notionapi.v1.GetTestCaseBlocksResponse
+	(*GetBlockRequest)(nil),           // 14: notionapi.v1.GetBlockRequest
+	(*Tags)(nil),                      // 15: notionapi.v1.Tags
+	(*UpdateTestCaseRequest)(nil),     // 16: notionapi.v1.UpdateTestCaseRequest
+	(*CreateTestCaseRequest)(nil),     // 17: notionapi.v1.CreateTestCaseRequest
+	(*UpdateTableRowRequest)(nil),     // 18: notionapi.v1.UpdateTableRowRequest
+	nil,                               // 19: notionapi.v1.TestStep.ExtraEntry
+	(*timestamppb.Timestamp)(nil),     // 20: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),             // 21: google.protobuf.Empty
+}
+var file_testcase_proto_depIdxs = []int32{
+	20, // 0: notionapi.v1.TestCase.last_edited:type_name -> google.protobuf.Timestamp
+	0,  // 1: notionapi.v1.DetailedTestCase.test_case:type_name -> notionapi.v1.TestCase
+	6,  // 2: notionapi.v1.DetailedTestCase.tables:type_name -> notionapi.v1.TableWithData
+	2,  // 3: notionapi.v1.DetailedTestCase.errors:type_name -> notionapi.v1.TestCaseError
+	4,  // 4: notionapi.v1.Block.table_info:type_name -> notionapi.v1.TableInfo
+	3,  // 5: notionapi.v1.Block.children:type_name -> notionapi.v1.Block
+	5,  // 6: notionapi.v1.TableInfo.rows:type_name -> notionapi.v1.TableRow
+	5,  // 7: notionapi.v1.TableWithData.rows:type_name -> notionapi.v1.TableRow
+	7,  // 8: notionapi.v1.TableWithData.steps:type_name -> notionapi.v1.TestStep
+	8,  // 9: notionapi.v1.TableWithData.warnings:type_name -> notionapi.v1.StepWarning
+	19, // 10: notionapi.v1.TestStep.extra:type_name -> notionapi.v1.TestStep.ExtraEntry
+	0,  // 11: notionapi.v1.ListTestCasesResponse.test_cases:type_name -> notionapi.v1.TestCase
+	0,  // 12: notionapi.v1.GetTestCaseBlocksResponse.test_case:type_name -> notionapi.v1.TestCase
+	3,  // 13: notionapi.v1.GetTestCaseBlocksResponse.blocks:type_name -> notionapi.v1.Block
+	15, // 14: notionapi.v1.UpdateTestCaseRequest.tags:type_name -> notionapi.v1.Tags
+	7,  // 15: notionapi.v1.CreateTestCaseRequest.steps:type_name -> notionapi.v1.TestStep
+	9,  // 16: notionapi.v1.TestCaseService.ListTestCases:input_type -> notionapi.v1.ListTestCasesRequest
+	9,  // 17: notionapi.v1.TestCaseService.StreamDetailedTestCases:input_type -> notionapi.v1.ListTestCasesRequest
+	11, // 18: notionapi.v1.TestCaseService.GetTestCase:input_type -> notionapi.v1.GetTestCaseRequest
+	12, // 19: notionapi.v1.TestCaseService.GetTestCaseBlocks:input_type -> notionapi.v1.GetTestCaseBlocksRequest
+	14, // 20: notionapi.v1.TestCaseService.GetBlock:input_type -> notionapi.v1.GetBlockRequest
+	14, // 21: notionapi.v1.TestCaseService.GetTable:input_type -> notionapi.v1.GetBlockRequest
+	16, // 22: notionapi.v1.TestCaseService.UpdateTestCase:input_type -> notionapi.v1.UpdateTestCaseRequest
+	17, // 23: notionapi.v1.TestCaseService.CreateTestCase:input_type -> notionapi.v1.CreateTestCaseRequest
+	18, // 24: notionapi.v1.TestCaseService.UpdateTableRow:input_type -> notionapi.v1.UpdateTableRowRequest
+	10, // 25: notionapi.v1.TestCaseService.ListTestCases:output_type -> notionapi.v1.ListTestCasesResponse
+	1,  // 26: notionapi.v1.TestCaseService.StreamDetailedTestCases:output_type -> notionapi.v1.DetailedTestCase
+	0,  // 27: notionapi.v1.TestCaseService.GetTestCase:output_type -> notionapi.v1.TestCase
+	13, // 28: notionapi.v1.TestCaseService.GetTestCaseBlocks:output_type -> notionapi.v1.GetTestCaseBlocksResponse
+	3,  // 29: notionapi.v1.TestCaseService.GetBlock:output_type -> notionapi.v1.Block
+	6,  // 30: notionapi.v1.TestCaseService.GetTable:output_type -> notionapi.v1.TableWithData
+	0,  // 31: notionapi.v1.TestCaseService.UpdateTestCase:output_type -> notionapi.v1.TestCase
+	0,  // 32: notionapi.v1.TestCaseService.CreateTestCase:output_type -> notionapi.v1.TestCase
+	21, // 33: notionapi.v1.TestCaseService.UpdateTableRow:output_type -> google.protobuf.Empty
+	25, // [25:34] is the sub-list for method output_type
+	16, // [16:25] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_testcase_proto_init() }
+func file_testcase_proto_init() {
+	if File_testcase_proto != nil {
+		return
+	}
+	file_testcase_proto_msgTypes[16].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_testcase_proto_rawDesc), len(file_testcase_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_testcase_proto_goTypes,
+		DependencyIndexes: file_testcase_proto_depIdxs,
+		MessageInfos:      file_testcase_proto_msgTypes,
+	}.Build()
+	File_testcase_proto = out.File
+	file_testcase_proto_goTypes = nil
+	file_testcase_proto_depIdxs = nil
+}
@@ -0,0 +1,430 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: testcase.proto
+
+package notionpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TestCaseService_ListTestCases_FullMethodName           = "/notionapi.v1.TestCaseService/ListTestCases"
+	TestCaseService_StreamDetailedTestCases_FullMethodName = "/notionapi.v1.TestCaseService/StreamDetailedTestCases"
+	TestCaseService_GetTestCase_FullMethodName             = "/notionapi.v1.TestCaseService/GetTestCase"
+	TestCaseService_GetTestCaseBlocks_FullMethodName       = "/notionapi.v1.TestCaseService/GetTestCaseBlocks"
+	TestCaseService_GetBlock_FullMethodName                = "/notionapi.v1.TestCaseService/GetBlock"
+	TestCaseService_GetTable_FullMethodName                = "/notionapi.v1.TestCaseService/GetTable"
+	TestCaseService_UpdateTestCase_FullMethodName          = "/notionapi.v1.TestCaseService/UpdateTestCase"
+	TestCaseService_CreateTestCase_FullMethodName          = "/notionapi.v1.TestCaseService/CreateTestCase"
+	TestCaseService_UpdateTableRow_FullMethodName          = "/notionapi.v1.TestCaseService/UpdateTableRow"
+)
+
+// TestCaseServiceClient is the client API for TestCaseService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TestCaseServiceClient interface {
+	ListTestCases(ctx context.Context, in *ListTestCasesRequest, opts ...grpc.CallOption) (*ListTestCasesResponse, error)
+	StreamDetailedTestCases(ctx context.Context, in *ListTestCasesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DetailedTestCase], error)
+	GetTestCase(ctx context.Context, in *GetTestCaseRequest, opts ...grpc.CallOption) (*TestCase, error)
+	GetTestCaseBlocks(ctx context.Context, in *GetTestCaseBlocksRequest, opts ...grpc.CallOption) (*GetTestCaseBlocksResponse, error)
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error)
+	GetTable(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*TableWithData, error)
+	UpdateTestCase(ctx context.Context, in *UpdateTestCaseRequest, opts ...grpc.CallOption) (*TestCase, error)
+	CreateTestCase(ctx context.Context, in *CreateTestCaseRequest, opts ...grpc.CallOption) (*TestCase, error)
+	UpdateTableRow(ctx context.Context, in *UpdateTableRowRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type testCaseServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTestCaseServiceClient(cc grpc.ClientConnInterface) TestCaseServiceClient {
+	return &testCaseServiceClient{cc}
+}
+
+func (c *testCaseServiceClient) ListTestCases(ctx context.Context, in *ListTestCasesRequest, opts ...grpc.CallOption) (*ListTestCasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTestCasesResponse)
+	err := c.cc.Invoke(ctx, TestCaseService_ListTestCases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testCaseServiceClient) StreamDetailedTestCases(ctx context.Context, in *ListTestCasesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DetailedTestCase], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TestCaseService_ServiceDesc.Streams[0], TestCaseService_StreamDetailedTestCases_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListTestCasesRequest, DetailedTestCase]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TestCaseService_StreamDetailedTestCasesClient = grpc.ServerStreamingClient[DetailedTestCase]
+
+func (c *testCaseServiceClient) GetTestCase(ctx context.Context, in *GetTestCaseRequest, opts ...grpc.CallOption) (*TestCase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestCase)
+	err := c.cc.Invoke(ctx, TestCaseService_GetTestCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testCaseServiceClient) GetTestCaseBlocks(ctx context.Context, in *GetTestCaseBlocksRequest, opts ...grpc.CallOption) (*GetTestCaseBlocksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTestCaseBlocksResponse)
+	err := c.cc.Invoke(ctx, TestCaseService_GetTestCaseBlocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testCaseServiceClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Block)
+	err := c.cc.Invoke(ctx, TestCaseService_GetBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testCaseServiceClient) GetTable(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*TableWithData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TableWithData)
+	err := c.cc.Invoke(ctx, TestCaseService_GetTable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testCaseServiceClient) UpdateTestCase(ctx context.Context, in *UpdateTestCaseRequest, opts ...grpc.CallOption) (*TestCase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestCase)
+	err := c.cc.Invoke(ctx, TestCaseService_UpdateTestCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testCaseServiceClient) CreateTestCase(ctx context.Context, in *CreateTestCaseRequest, opts ...grpc.CallOption) (*TestCase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestCase)
+	err := c.cc.Invoke(ctx, TestCaseService_CreateTestCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testCaseServiceClient) UpdateTableRow(ctx context.Context, in *UpdateTableRowRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TestCaseService_UpdateTableRow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TestCaseServiceServer is the server API for TestCaseService service.
+// All implementations must embed UnimplementedTestCaseServiceServer
+// for forward compatibility.
+type TestCaseServiceServer interface {
+	ListTestCases(context.Context, *ListTestCasesRequest) (*ListTestCasesResponse, error)
+	StreamDetailedTestCases(*ListTestCasesRequest, grpc.ServerStreamingServer[DetailedTestCase]) error
+	GetTestCase(context.Context, *GetTestCaseRequest) (*TestCase, error)
+	GetTestCaseBlocks(context.Context, *GetTestCaseBlocksRequest) (*GetTestCaseBlocksResponse, error)
+	GetBlock(context.Context, *GetBlockRequest) (*Block, error)
+	GetTable(context.Context, *GetBlockRequest) (*TableWithData, error)
+	UpdateTestCase(context.Context, *UpdateTestCaseRequest) (*TestCase, error)
+	CreateTestCase(context.Context, *CreateTestCaseRequest) (*TestCase, error)
+	UpdateTableRow(context.Context, *UpdateTableRowRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedTestCaseServiceServer()
+}
+
+// UnimplementedTestCaseServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTestCaseServiceServer struct{}
+
+func (UnimplementedTestCaseServiceServer) ListTestCases(context.Context, *ListTestCasesRequest) (*ListTestCasesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTestCases not implemented")
+}
+func (UnimplementedTestCaseServiceServer) StreamDetailedTestCases(*ListTestCasesRequest, grpc.ServerStreamingServer[DetailedTestCase]) error {
+	return status.Error(codes.Unimplemented, "method StreamDetailedTestCases not implemented")
+}
+func (UnimplementedTestCaseServiceServer) GetTestCase(context.Context, *GetTestCaseRequest) (*TestCase, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTestCase not implemented")
+}
+func (UnimplementedTestCaseServiceServer) GetTestCaseBlocks(context.Context, *GetTestCaseBlocksRequest) (*GetTestCaseBlocksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTestCaseBlocks not implemented")
+}
+func (UnimplementedTestCaseServiceServer) GetBlock(context.Context, *GetBlockRequest) (*Block, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedTestCaseServiceServer) GetTable(context.Context, *GetBlockRequest) (*TableWithData, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTable not implemented")
+}
+func (UnimplementedTestCaseServiceServer) UpdateTestCase(context.Context, *UpdateTestCaseRequest) (*TestCase, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateTestCase not implemented")
+}
+func (UnimplementedTestCaseServiceServer) CreateTestCase(context.Context, *CreateTestCaseRequest) (*TestCase, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateTestCase not implemented")
+}
+func (UnimplementedTestCaseServiceServer) UpdateTableRow(context.Context, *UpdateTableRowRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateTableRow not implemented")
+}
+func (UnimplementedTestCaseServiceServer) mustEmbedUnimplementedTestCaseServiceServer() {}
+func (UnimplementedTestCaseServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeTestCaseServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TestCaseServiceServer will
+// result in compilation errors.
+type UnsafeTestCaseServiceServer interface {
+	mustEmbedUnimplementedTestCaseServiceServer()
+}
+
+func RegisterTestCaseServiceServer(s grpc.ServiceRegistrar, srv TestCaseServiceServer) {
+	// If the following call panics, it indicates UnimplementedTestCaseServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TestCaseService_ServiceDesc, srv)
+}
+
+func _TestCaseService_ListTestCases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTestCasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestCaseServiceServer).ListTestCases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestCaseService_ListTestCases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestCaseServiceServer).ListTestCases(ctx, req.(*ListTestCasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestCaseService_StreamDetailedTestCases_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTestCasesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TestCaseServiceServer).StreamDetailedTestCases(m, &grpc.GenericServerStream[ListTestCasesRequest, DetailedTestCase]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TestCaseService_StreamDetailedTestCasesServer = grpc.ServerStreamingServer[DetailedTestCase]
+
+func _TestCaseService_GetTestCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTestCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestCaseServiceServer).GetTestCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestCaseService_GetTestCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestCaseServiceServer).GetTestCase(ctx, req.(*GetTestCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestCaseService_GetTestCaseBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTestCaseBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestCaseServiceServer).GetTestCaseBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestCaseService_GetTestCaseBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestCaseServiceServer).GetTestCaseBlocks(ctx, req.(*GetTestCaseBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestCaseService_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestCaseServiceServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestCaseService_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestCaseServiceServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestCaseService_GetTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestCaseServiceServer).GetTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestCaseService_GetTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestCaseServiceServer).GetTable(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestCaseService_UpdateTestCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTestCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestCaseServiceServer).UpdateTestCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestCaseService_UpdateTestCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestCaseServiceServer).UpdateTestCase(ctx, req.(*UpdateTestCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestCaseService_CreateTestCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTestCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestCaseServiceServer).CreateTestCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestCaseService_CreateTestCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestCaseServiceServer).CreateTestCase(ctx, req.(*CreateTestCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestCaseService_UpdateTableRow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTableRowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestCaseServiceServer).UpdateTableRow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TestCaseService_UpdateTableRow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestCaseServiceServer).UpdateTableRow(ctx, req.(*UpdateTableRowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TestCaseService_ServiceDesc is the grpc.ServiceDesc for TestCaseService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TestCaseService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "notionapi.v1.TestCaseService",
+	HandlerType: (*TestCaseServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTestCases",
+			Handler:    _TestCaseService_ListTestCases_Handler,
+		},
+		{
+			MethodName: "GetTestCase",
+			Handler:    _TestCaseService_GetTestCase_Handler,
+		},
+		{
+			MethodName: "GetTestCaseBlocks",
+			Handler:    _TestCaseService_GetTestCaseBlocks_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _TestCaseService_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetTable",
+			Handler:    _TestCaseService_GetTable_Handler,
+		},
+		{
+			MethodName: "UpdateTestCase",
+			Handler:    _TestCaseService_UpdateTestCase_Handler,
+		},
+		{
+			MethodName: "CreateTestCase",
+			Handler:    _TestCaseService_CreateTestCase_Handler,
+		},
+		{
+			MethodName: "UpdateTableRow",
+			Handler:    _TestCaseService_UpdateTableRow_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamDetailedTestCases",
+			Handler:       _TestCaseService_StreamDetailedTestCases_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "testcase.proto",
+}
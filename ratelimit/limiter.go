@@ -0,0 +1,148 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often buckets that have refilled are dropped
+const sweepInterval = time.Minute
+
+// Limiter is a token bucket per key. Each bucket holds up to burst tokens and
+// refills at rate tokens per second; every request takes one token.
+type Limiter struct {
+	name  string
+	rate  float64
+	burst int
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Result is the state of a bucket after a request
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long until the next token; zero when allowed
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+}
+
+// New builds a limiter from a rate such as "120/m" and a burst. It returns nil
+// when the rate is empty or zero, which disables limiting.
+func New(name, rate, burst string) (*Limiter, error) {
+	perSecond, err := ParseRate(rate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s rate limit %q: %w", name, rate, err)
+	}
+	if perSecond == 0 {
+		return nil, nil
+	}
+
+	size, err := strconv.Atoi(burst)
+	if err != nil || size < 1 {
+		return nil, fmt.Errorf("invalid %s rate limit burst %q: must be a positive number", name, burst)
+	}
+
+	return &Limiter{
+		name:    name,
+		rate:    perSecond,
+		burst:   size,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}, nil
+}
+
+// ParseRate reads "<count>/<s|m|h>" as tokens per second; "" and "0" mean no limit
+func ParseRate(rate string) (float64, error) {
+	rate = strings.TrimSpace(rate)
+	if rate == "" || rate == "0" {
+		return 0, nil
+	}
+
+	count, unit, ok := strings.Cut(rate, "/")
+	if !ok {
+		return 0, fmt.Errorf("expected <count>/<s|m|h>")
+	}
+	n, err := strconv.ParseFloat(count, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("count must be a positive number")
+	}
+
+	switch unit {
+	case "s":
+		return n, nil
+	case "m":
+		return n / 60, nil
+	case "h":
+		return n / 3600, nil
+	}
+	return 0, fmt.Errorf("unit must be s, m or h")
+}
+
+// Name identifies the limiter in logs
+func (l *Limiter) Name() string {
+	return l.name
+}
+
+// Allow takes a token from the bucket of key if there is one
+func (l *Limiter) Allow(key string) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(l.burst), b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+
+	result := Result{Limit: l.burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = l.duration(1 - b.tokens)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = l.duration(float64(l.burst) - b.tokens)
+	return result
+}
+
+// Helper methods
+
+// sweep drops the buckets that are full again, as they behave like new ones
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.rate >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// duration is the time to refill tokens
+func (l *Limiter) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		rate    string
+		want    float64
+		wantErr bool
+	}{
+		{rate: "", want: 0},
+		{rate: "0", want: 0},
+		{rate: "5/s", want: 5},
+		{rate: " 120/m ", want: 2},
+		{rate: "1800/h", want: 0.5},
+		{rate: "120", wantErr: true},
+		{rate: "x/m", wantErr: true},
+		{rate: "-1/m", wantErr: true},
+		{rate: "10/d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRate(tt.rate)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRate(%q) error = %v, wantErr %v", tt.rate, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRate(%q) = %v, want %v", tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name, rate, burst string
+		wantNil, wantErr  bool
+	}{
+		{name: "limited", rate: "60/m", burst: "3"},
+		{name: "disabled", rate: "0", burst: "3", wantNil: true},
+		{name: "bad rate", rate: "fast", burst: "3", wantErr: true},
+		{name: "bad burst", rate: "60/m", burst: "0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter, err := New("test", tt.rate, tt.burst)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (limiter == nil) != tt.wantNil {
+				t.Errorf("New() = %v, want nil %v", limiter, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	limiter, err := New("test", "1/s", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiter.now = func() time.Time { return now }
+
+	steps := []struct {
+		name          string
+		at            time.Duration
+		key           string
+		wantAllowed   bool
+		wantRemaining int
+		wantRetry     time.Duration
+		wantReset     time.Duration
+	}{
+		{name: "full bucket", at: 0, key: "a", wantAllowed: true, wantRemaining: 1, wantReset: time.Second},
+		{name: "last token", at: 0, key: "a", wantAllowed: true, wantRemaining: 0, wantReset: 2 * time.Second},
+		{name: "empty bucket", at: 0, key: "a", wantRetry: time.Second, wantReset: 2 * time.Second},
+		{name: "other keys have their own bucket", at: 0, key: "b", wantAllowed: true, wantRemaining: 1, wantReset: time.Second},
+		{name: "half a token", at: 500 * time.Millisecond, key: "a", wantRetry: 500 * time.Millisecond, wantReset: 1500 * time.Millisecond},
+		{name: "refilled token", at: time.Second, key: "a", wantAllowed: true, wantRemaining: 0, wantReset: 2 * time.Second},
+		{name: "refill stops at burst", at: time.Hour, key: "a", wantAllowed: true, wantRemaining: 1, wantReset: time.Second},
+	}
+
+	for _, step := range steps {
+		now = start.Add(step.at)
+		result := limiter.Allow(step.key)
+		want := Result{
+			Allowed:    step.wantAllowed,
+			Limit:      2,
+			Remaining:  step.wantRemaining,
+			RetryAfter: step.wantRetry,
+			Reset:      step.wantReset,
+		}
+		if result != want {
+			t.Errorf("%s: Allow() = %+v, want %+v", step.name, result, want)
+		}
+	}
+}
+
+func TestLimiterSweepsFullBuckets(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	limiter, err := New("test", "1/s", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiter.now = func() time.Time { return now }
+
+	limiter.Allow("a")
+	now = start.Add(sweepInterval)
+	limiter.Allow("b")
+
+	if _, ok := limiter.buckets["a"]; ok {
+		t.Error("bucket of a is kept after it refilled")
+	}
+	if _, ok := limiter.buckets["b"]; !ok {
+		t.Error("bucket of b is dropped")
+	}
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"demo-notion-api/auth"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware limits requests per API key or token subject, or per client IP
+// for anonymous callers. It sets the X-RateLimit headers on every response and
+// answers 429 with Retry-After when the bucket is empty. A nil limiter lets
+// every request through.
+func Middleware(limiter *Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limiter == nil {
+			c.Next()
+			return
+		}
+
+		key := Key(c)
+		result := limiter.Allow(key)
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+
+		if !result.Allowed {
+			slog.WarnContext(c.Request.Context(), "rate limited",
+				slog.String("key", key),
+				slog.String("limit", limiter.Name()),
+				slog.String("path", c.Request.URL.Path),
+			)
+			c.Header("Retry-After", strconv.Itoa(seconds(result.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":   "Too many requests",
+				"message": fmt.Sprintf("%s rate limit exceeded, retry in %d seconds", limiter.Name(), seconds(result.RetryAfter)),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// Key identifies the caller of a request for rate limiting
+func Key(c *gin.Context) string {
+	if identity, ok := auth.IdentityFrom(c.Request.Context()); ok {
+		return identity.Method + ":" + identity.Subject
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// Helper functions
+
+// seconds rounds up, so that a client waiting Retry-After finds a token
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"demo-notion-api/auth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		identity       *auth.Identity
+		want           string
+	}{
+		{
+			name:         "forwarded header is ignored without trusted proxies",
+			remoteAddr:   "203.0.113.7:4000",
+			forwardedFor: "198.51.100.1",
+			want:         "ip:203.0.113.7",
+		},
+		{
+			name:           "forwarded header of a trusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.1.2.3:4000",
+			forwardedFor:   "198.51.100.1",
+			want:           "ip:198.51.100.1",
+		},
+		{
+			name:           "forwarded header of an untrusted peer",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "203.0.113.7:4000",
+			forwardedFor:   "198.51.100.1",
+			want:           "ip:203.0.113.7",
+		},
+		{
+			name:         "authenticated callers are keyed by subject",
+			remoteAddr:   "203.0.113.7:4000",
+			forwardedFor: "198.51.100.1",
+			identity:     &auth.Identity{Subject: "ci", Method: "api_key"},
+			want:         "api_key:ci",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			if err := r.SetTrustedProxies(tt.trustedProxies); err != nil {
+				t.Fatal(err)
+			}
+
+			var got string
+			r.GET("/", func(c *gin.Context) {
+				if tt.identity != nil {
+					c.Request = c.Request.WithContext(auth.WithIdentity(c.Request.Context(), tt.identity))
+				}
+				got = Key(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("Key() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"demo-notion-api/models"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Spreadsheet columns, one row per step with the test case columns repeated
+const (
+	ColumnProject        = "Project"
+	ColumnTestCaseKey    = "Test Case Key"
+	ColumnTitle          = "Title"
+	ColumnStatus         = "Status"
+	ColumnTestDate       = "Test Date"
+	ColumnTags           = "Tags"
+	ColumnURL            = "URL"
+	ColumnStep           = "Step"
+	ColumnAction         = "Action"
+	ColumnExpectedResult = "Expected Result"
+	ColumnActualResult   = "Actual Result"
+	ColumnStepStatus     = "Step Status"
+	ColumnScreenshot     = "Screenshot"
+)
+
+// SpreadsheetColumns is the header row of exported spreadsheets
+var SpreadsheetColumns = []string{
+	ColumnProject,
+	ColumnTestCaseKey,
+	ColumnTitle,
+	ColumnStatus,
+	ColumnTestDate,
+	ColumnTags,
+	ColumnURL,
+	ColumnStep,
+	ColumnAction,
+	ColumnExpectedResult,
+	ColumnActualResult,
+	ColumnStepStatus,
+	ColumnScreenshot,
+}
+
+// unassignedSheet holds test cases that belong to no suite
+const unassignedSheet = "Unassigned"
+
+// ExportSheet is a named sheet of spreadsheet rows, without the header row
+type ExportSheet struct {
+	Name string
+	Rows [][]string
+}
+
+type ExportService struct {
+	suiteService *SuiteService
+}
+
+func NewExportService(suiteService *SuiteService) *ExportService {
+	return &ExportService{
+		suiteService: suiteService,
+	}
+}
+
+// WithContext returns a copy of the service whose Notion calls carry ctx
+func (s *ExportService) WithContext(ctx context.Context) *ExportService {
+	return &ExportService{suiteService: s.suiteService.WithContext(ctx)}
+}
+
+// ExportRows flattens every detailed test case into one row per step. The
+// test cases are returned too, so that callers can check them with
+// CheckComplete; test cases with steps that are not exported carry an error.
+func (s *ExportService) ExportRows() (rows [][]string, testCases []models.DetailedTestCaseResponse, err error) {
+	detailed, err := s.suiteService.NotionService().GetDetailedTestCases()
+	if err != nil {
+		return nil, nil, err
+	}
+	flagUnexportedTables(detailed)
+
+	for _, tc := range detailed {
+		rows = append(rows, FlattenTestCase(tc)...)
+	}
+	return rows, detailed, nil
+}
+
+// ExportSheets flattens detailed test cases into one sheet per suite.
+// Test cases in several suites appear on each of their sheets; test cases in
+// no suite go to an "Unassigned" sheet. Without suites a single sheet is
+// returned. The test cases are returned as in ExportRows.
+func (s *ExportService) ExportSheets() (sheets []ExportSheet, testCases []models.DetailedTestCaseResponse, err error) {
+	detailed, err := s.suiteService.NotionService().GetDetailedTestCases()
+	if err != nil {
+		return nil, nil, err
+	}
+	flagUnexportedTables(detailed)
+
+	suites := s.suiteService.ListSuites()
+	if len(suites) == 0 {
+		sheet := ExportSheet{Name: "Test Cases"}
+		for _, tc := range detailed {
+			sheet.Rows = append(sheet.Rows, FlattenTestCase(tc)...)
+		}
+		return []ExportSheet{sheet}, detailed, nil
+	}
+
+	sheetRows := make(map[string][][]string)
+	for _, tc := range detailed {
+		rows := FlattenTestCase(tc)
+		names := s.suiteService.SuitesFor(summaryOf(tc))
+		if len(names) == 0 {
+			names = []string{unassignedSheet}
+		}
+		for _, name := range names {
+			sheetRows[name] = append(sheetRows[name], rows...)
+		}
+	}
+
+	for _, suite := range suites {
+		sheets = append(sheets, ExportSheet{Name: suite.Name, Rows: sheetRows[suite.Name]})
+	}
+	if rows, ok := sheetRows[unassignedSheet]; ok {
+		sheets = append(sheets, ExportSheet{Name: unassignedSheet, Rows: rows})
+	}
+	return sheets, detailed, nil
+}
+
+// FlattenTestCase returns one row per step of a test case in SpreadsheetColumns order.
+// Only the step table the importer reads is exported, so that an unchanged
+// export imports without changes; flagUnexportedTables reports the others.
+// A test case without steps yields a single row with empty step columns.
+func FlattenTestCase(tc models.DetailedTestCaseResponse) [][]string {
+	base := []string{
+		tc.Project,
+		tc.TestCaseKey,
+		tc.Title,
+		tc.Status,
+		tc.TestDate,
+		strings.Join(tc.Tags, ", "),
+		tc.URL,
+	}
+
+	var rows [][]string
+	if table := stepTable(tc); table != nil {
+		for _, step := range table.Steps {
+			row := append(append([]string{}, base...),
+				strconv.Itoa(step.Number),
+				step.Action,
+				step.ExpectedResult,
+				step.ActualResult,
+				step.Status,
+				step.Screenshot,
+			)
+			rows = append(rows, row)
+		}
+	}
+
+	if len(rows) == 0 {
+		rows = append(rows, append(base, "", "", "", "", "", ""))
+	}
+	return rows
+}
+
+// flagUnexportedTables adds an error to test cases with more than one step
+// table for each table after the first, whose steps are not exported
+func flagUnexportedTables(detailed []models.DetailedTestCaseResponse) {
+	for i, tc := range detailed {
+		exported := stepTable(tc)
+		for j := range tc.Tables {
+			table := &tc.Tables[j]
+			if len(table.Steps) == 0 || table == exported {
+				continue
+			}
+			detailed[i].Errors = append(detailed[i].Errors, models.TestCaseError{
+				TestCaseKey: tc.TestCaseKey,
+				PageID:      tc.PageID,
+				BlockID:     table.BlockID,
+				Message:     fmt.Sprintf("steps of table %s are not exported; only the first step table of a page is", table.BlockID),
+			})
+		}
+	}
+}
+
+// WriteCSV writes the header row followed by rows, with formula cells escaped
+func WriteCSV(w io.Writer, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(SpreadsheetColumns); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+	if err := writer.WriteAll(escapeRows(rows)); err != nil {
+		return fmt.Errorf("failed to write csv rows: %w", err)
+	}
+	return nil
+}
+
+// WriteXLSX writes a workbook with one worksheet per sheet, each with a header
+// row, with formula cells escaped
+func WriteXLSX(w io.Writer, sheets []ExportSheet) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	defaultSheet := f.GetSheetName(0)
+	used := make(map[string]bool)
+
+	for i, sheet := range sheets {
+		name := uniqueSheetName(sanitizeSheetName(sheet.Name), used)
+		if i == 0 {
+			if err := f.SetSheetName(defaultSheet, name); err != nil {
+				return fmt.Errorf("failed to name sheet %s: %w", name, err)
+			}
+		} else if _, err := f.NewSheet(name); err != nil {
+			return fmt.Errorf("failed to create sheet %s: %w", name, err)
+		}
+
+		if err := f.SetSheetRow(name, "A1", &SpreadsheetColumns); err != nil {
+			return fmt.Errorf("failed to write header of sheet %s: %w", name, err)
+		}
+		for r, row := range escapeRows(sheet.Rows) {
+			cell, _ := excelize.CoordinatesToCellName(1, r+2)
+			if err := f.SetSheetRow(name, cell, &row); err != nil {
+				return fmt.Errorf("failed to write row %d of sheet %s: %w", r+2, name, err)
+			}
+		}
+	}
+
+	if err := f.Write(w); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
+
+// Helper functions
+
+func summaryOf(tc models.DetailedTestCaseResponse) models.TestCaseResponse {
+	return models.TestCaseResponse{
+		Project:      tc.Project,
+		TestCaseKey:  tc.TestCaseKey,
+		PageID:       tc.PageID,
+		Title:        tc.Title,
+		Status:       tc.Status,
+		TestDate:     tc.TestDate,
+		Tags:         tc.Tags,
+		Assignees:    tc.Assignees,
+		URL:          tc.URL,
+		LastEdited:   tc.LastEdited,
+		LastEditedBy: tc.LastEditedBy,
+	}
+}
+
+// escapeCell prefixes cells that spreadsheet apps would run as a formula
+// with a quote, and doubles the quote of cells already starting with one so
+// that unescapeCell restores every cell exactly
+func escapeCell(value string) string {
+	if value != "" && (isFormulaStart(value[0]) || value[0] == '\'') {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeCell removes the quote added by escapeCell
+func unescapeCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && (isFormulaStart(value[1]) || value[1] == '\'') {
+		return value[1:]
+	}
+	return value
+}
+
+func isFormulaStart(c byte) bool {
+	return strings.IndexByte("=+-@\t\r", c) >= 0
+}
+
+func escapeRows(rows [][]string) [][]string {
+	escaped := make([][]string, len(rows))
+	for i, row := range rows {
+		escaped[i] = make([]string, len(row))
+		for j, value := range row {
+			escaped[i][j] = escapeCell(value)
+		}
+	}
+	return escaped
+}
+
+// sanitizeSheetName drops characters Excel rejects and truncates to 31 characters
+func sanitizeSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, strings.Trim(name, "'"))
+	if name == "" {
+		name = "Sheet"
+	}
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
+
+func uniqueSheetName(name string, used map[string]bool) string {
+	candidate := name
+	for i := 2; used[strings.ToLower(candidate)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		runes := []rune(name)
+		if len(runes)+len(suffix) > 31 {
+			runes = runes[:31-len(suffix)]
+		}
+		candidate = string(runes) + suffix
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
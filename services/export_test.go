@@ -0,0 +1,54 @@
+package services
+
+import (
+	"demo-notion-api/models"
+	"reflect"
+	"testing"
+)
+
+func TestFlagUnexportedTables(t *testing.T) {
+	steps := []models.TestStep{{Number: 1, Action: "Open"}}
+	readError := models.TestCaseError{TestCaseKey: "01001", PageID: "page", Message: "failed to get table data"}
+
+	tests := []struct {
+		name   string
+		tables []models.TableWithData
+		errors []models.TestCaseError
+		want   []string
+	}{
+		{name: "no tables"},
+		{name: "one step table", tables: []models.TableWithData{{BlockID: "env"}, {BlockID: "steps", Steps: steps}}},
+		{
+			name:   "later step tables",
+			tables: []models.TableWithData{{BlockID: "env"}, {BlockID: "steps", Steps: steps}, {BlockID: "more", Steps: steps}, {BlockID: "last", Steps: steps}},
+			want:   []string{"more", "last"},
+		},
+		{
+			name:   "read errors are kept",
+			tables: []models.TableWithData{{BlockID: "steps", Steps: steps}, {BlockID: "more", Steps: steps}},
+			errors: []models.TestCaseError{readError},
+			want:   []string{"", "more"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detailed := []models.DetailedTestCaseResponse{{TestCaseKey: "01001", PageID: "page", Tables: tt.tables, Errors: tt.errors}}
+			flagUnexportedTables(detailed)
+
+			var blocks []string
+			for _, err := range detailed[0].Errors {
+				blocks = append(blocks, err.BlockID)
+				if err.TestCaseKey != "01001" || err.PageID != "page" {
+					t.Errorf("error = %+v, want it to name the test case", err)
+				}
+			}
+			if !reflect.DeepEqual(blocks, tt.want) {
+				t.Errorf("flagged blocks = %q, want %q", blocks, tt.want)
+			}
+			if (CheckComplete(detailed) != nil) != (len(tt.want) > 0) {
+				t.Errorf("CheckComplete() = %v, want an error only when tables are flagged", CheckComplete(detailed))
+			}
+		})
+	}
+}
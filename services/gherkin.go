@@ -0,0 +1,324 @@
+package services
+
+import (
+	"bufio"
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"fmt"
+	"strings"
+)
+
+// Gherkin step keywords
+const (
+	keywordGiven = "Given"
+	keywordWhen  = "When"
+	keywordThen  = "Then"
+	keywordAnd   = "And"
+	keywordBut   = "But"
+)
+
+// keywordColumn is the step table column that overrides the positional keyword
+const keywordColumn = "keyword"
+
+// GherkinScenario is a scenario parsed from a feature file
+type GherkinScenario struct {
+	Name  string
+	Tags  []string
+	Steps []GherkinStep
+	Line  int
+}
+
+type GherkinStep struct {
+	Keyword string
+	Text    string
+}
+
+// RenderFeature renders test cases as a feature file with one scenario per test case.
+// The key match of the title becomes a scenario tag, and so do the test case tags.
+// Test cases whose tables could not be read get a comment naming the errors.
+func RenderFeature(name string, testCases []models.DetailedTestCaseResponse, schema *config.Schema) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Feature: %s\n", name)
+
+	for _, tc := range testCases {
+		b.WriteString("\n")
+
+		tags := []string{}
+		if keyTag := schema.KeyRegexp().FindString(tc.Title); keyTag != "" {
+			tags = append(tags, "@"+gherkinTag(keyTag))
+		}
+		for _, tag := range tc.Tags {
+			tags = append(tags, "@"+gherkinTag(tag))
+		}
+		for _, readErr := range tc.Errors {
+			fmt.Fprintf(&b, "  # Incomplete: %s\n", singleLine(readErr.Message))
+		}
+		if len(tags) > 0 {
+			fmt.Fprintf(&b, "  %s\n", strings.Join(tags, " "))
+		}
+
+		fmt.Fprintf(&b, "  Scenario: %s\n", singleLine(tc.Title))
+
+		previous := ""
+		for _, step := range ScenarioSteps(tc) {
+			keyword := step.Keyword
+			if keyword == keywordThen && previous == keywordThen {
+				keyword = keywordAnd
+			}
+			previous = step.Keyword
+			fmt.Fprintf(&b, "    %s %s\n", keyword, singleLine(step.Text))
+		}
+	}
+
+	return b.String()
+}
+
+// ScenarioSteps maps the step table of a test case to Gherkin steps.
+// The first action is a Given and later actions are Whens, unless the step has
+// a Keyword column; every expected result becomes a Then. Only the table that
+// ImportFeature writes (see stepTable) is rendered, so that a rendered feature
+// imports without changes.
+func ScenarioSteps(tc models.DetailedTestCaseResponse) []GherkinStep {
+	table := stepTable(tc)
+	if table == nil {
+		return nil
+	}
+
+	var steps []GherkinStep
+	for position, step := range table.Steps {
+		if step.Action != "" {
+			keyword := keywordWhen
+			if position == 0 {
+				keyword = keywordGiven
+			}
+			if explicit := stepKeyword(step); explicit != "" {
+				keyword = explicit
+			}
+			steps = append(steps, GherkinStep{Keyword: keyword, Text: step.Action})
+		}
+		if step.ExpectedResult != "" {
+			steps = append(steps, GherkinStep{Keyword: keywordThen, Text: step.ExpectedResult})
+		}
+	}
+
+	return steps
+}
+
+// ParseFeature reads the scenarios of a feature file. Background steps are
+// prepended to every scenario; scenario outlines are read as plain scenarios.
+// Doc strings and data table lines are appended to the preceding step.
+func ParseFeature(text string) ([]GherkinScenario, error) {
+	var scenarios []GherkinScenario
+	var background []GherkinStep
+	var pendingTags []string
+	var current *GherkinScenario
+	inBackground := false
+	inDocString := false
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if inDocString || strings.HasPrefix(line, `"""`) || strings.HasPrefix(line, "|") {
+			if strings.HasPrefix(line, `"""`) {
+				inDocString = !inDocString
+				continue
+			}
+			steps := &background
+			if current != nil {
+				steps = &current.Steps
+			}
+			if len(*steps) > 0 {
+				last := &(*steps)[len(*steps)-1]
+				last.Text += "\n" + line
+			}
+			continue
+		}
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+
+		case strings.HasPrefix(line, "@"):
+			for _, tag := range strings.Fields(line) {
+				pendingTags = append(pendingTags, strings.TrimPrefix(tag, "@"))
+			}
+
+		case strings.HasPrefix(line, "Feature:"), strings.HasPrefix(line, "Rule:"):
+			pendingTags = nil
+
+		case strings.HasPrefix(line, "Background:"):
+			inBackground = true
+			current = nil
+
+		case strings.HasPrefix(line, "Scenario:"), strings.HasPrefix(line, "Scenario Outline:"), strings.HasPrefix(line, "Example:"):
+			name := strings.TrimSpace(line[strings.Index(line, ":")+1:])
+			scenarios = append(scenarios, GherkinScenario{
+				Name:  name,
+				Tags:  pendingTags,
+				Steps: append([]GherkinStep{}, background...),
+				Line:  lineNumber,
+			})
+			current = &scenarios[len(scenarios)-1]
+			pendingTags = nil
+			inBackground = false
+
+		case strings.HasPrefix(line, "Examples:"):
+			current = nil
+
+		default:
+			keyword, rest, ok := splitStepKeyword(line)
+			if !ok {
+				if current == nil && !inBackground {
+					continue
+				}
+				return nil, fmt.Errorf("line %d: unexpected %q", lineNumber, line)
+			}
+			step := GherkinStep{Keyword: keyword, Text: rest}
+			if inBackground {
+				background = append(background, step)
+			} else if current != nil {
+				current.Steps = append(current.Steps, step)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read feature: %w", err)
+	}
+	return scenarios, nil
+}
+
+// ImportFeature creates or updates test cases from feature file scenarios.
+// The key is taken from a scenario tag or the scenario name; titles that do
+// not carry the key get it prefixed. Titles and step tables are compared as in
+// spreadsheet imports; tags and statuses are left untouched.
+func (s *ImportService) ImportFeature(scenarios []GherkinScenario, opts ImportOptions) (*models.ImportResult, error) {
+	schema := s.notionService.Schema()
+	columns := importColumns{
+		ColumnTitle:          true,
+		ColumnStep:           true,
+		ColumnAction:         true,
+		ColumnExpectedResult: true,
+	}
+
+	var imported []importedTestCase
+	var errs []models.ImportError
+	seen := make(map[string]bool)
+
+	for _, scenario := range scenarios {
+		key, prefix := "", ""
+		for _, tag := range scenario.Tags {
+			if key = schema.ExtractKey(tag); key != "" {
+				prefix = schema.KeyRegexp().FindString(tag)
+				break
+			}
+		}
+		if key == "" {
+			key = schema.ExtractKey(scenario.Name)
+		}
+		if key == "" {
+			errs = append(errs, models.ImportError{Row: scenario.Line, Message: fmt.Sprintf("scenario %q has no test case key", scenario.Name)})
+			continue
+		}
+		if seen[key] {
+			errs = append(errs, models.ImportError{Row: scenario.Line, TestCaseKey: key, Message: "test case key is used by more than one scenario"})
+			continue
+		}
+		seen[key] = true
+
+		title := scenario.Name
+		if schema.ExtractKey(title) != key {
+			title = strings.TrimSpace(prefix + " " + title)
+		}
+
+		steps := stepsFromScenario(scenario.Steps)
+		imported = append(imported, importedTestCase{
+			Key:      key,
+			Title:    title,
+			Steps:    steps,
+			HasSteps: len(steps) > 0,
+		})
+	}
+
+	return s.importTestCases(imported, columns, errs, opts)
+}
+
+// Helper functions
+
+// stepsFromScenario folds Gherkin steps back into table steps: Given and When
+// start a new step with that action, Then fills in the expected result, and
+// And or But continue whichever part came before. RenderFeature only uses And
+// after Then, so rendered features read back into the same steps.
+func stepsFromScenario(gherkinSteps []GherkinStep) []models.TestStep {
+	var steps []models.TestStep
+	lastKind := ""
+
+	for _, gs := range gherkinSteps {
+		kind := gs.Keyword
+		if kind == keywordAnd || kind == keywordBut || kind == "*" {
+			kind = lastKind
+		}
+
+		switch kind {
+		case keywordThen:
+			if len(steps) == 0 || (steps[len(steps)-1].ExpectedResult != "" && lastKind != keywordThen) {
+				steps = append(steps, models.TestStep{Number: len(steps) + 1})
+			}
+			last := &steps[len(steps)-1]
+			last.ExpectedResult = joinLines(last.ExpectedResult, gs.Text)
+		default:
+			continuation := gs.Keyword != kind
+			if continuation && len(steps) > 0 && steps[len(steps)-1].ExpectedResult == "" {
+				last := &steps[len(steps)-1]
+				last.Action = joinLines(last.Action, gs.Text)
+			} else {
+				steps = append(steps, models.TestStep{Number: len(steps) + 1, Action: gs.Text})
+			}
+		}
+		lastKind = kind
+	}
+
+	return steps
+}
+
+func splitStepKeyword(line string) (string, string, bool) {
+	for _, keyword := range []string{keywordGiven, keywordWhen, keywordThen, keywordAnd, keywordBut, "*"} {
+		if strings.HasPrefix(line, keyword+" ") {
+			return keyword, strings.TrimSpace(line[len(keyword):]), true
+		}
+	}
+	return "", "", false
+}
+
+// stepKeyword returns the Given/When/Then keyword of a step's Keyword column, if any
+func stepKeyword(step models.TestStep) string {
+	for column, value := range step.Extra {
+		if !strings.EqualFold(strings.TrimSpace(column), keywordColumn) {
+			continue
+		}
+		for _, keyword := range []string{keywordGiven, keywordWhen, keywordThen} {
+			if strings.EqualFold(strings.TrimSpace(value), keyword) {
+				return keyword
+			}
+		}
+	}
+	return ""
+}
+
+func gherkinTag(value string) string {
+	return strings.Join(strings.Fields(value), "_")
+}
+
+func singleLine(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func joinLines(existing, addition string) string {
+	if existing == "" {
+		return addition
+	}
+	return existing + "\n" + addition
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFeature(t *testing.T) {
+	tests := []struct {
+		name    string
+		feature string
+		want    []GherkinScenario
+		wantErr string
+	}{
+		{
+			name: "tags, comments and description",
+			feature: `@feature-tag
+Feature: Login
+  Users log in with their password.
+
+  # a comment
+  @TC_01001 @smoke
+  Scenario: Login succeeds
+    Given the login page
+    When I log in
+    Then I see the dashboard
+    And I see my name
+`,
+			want: []GherkinScenario{{
+				Name: "Login succeeds",
+				Tags: []string{"TC_01001", "smoke"},
+				Line: 7,
+				Steps: []GherkinStep{
+					{Keyword: "Given", Text: "the login page"},
+					{Keyword: "When", Text: "I log in"},
+					{Keyword: "Then", Text: "I see the dashboard"},
+					{Keyword: "And", Text: "I see my name"},
+				},
+			}},
+		},
+		{
+			name: "background is prepended to every scenario",
+			feature: `Feature: Login
+  Background:
+    Given the login page
+
+  Scenario: TC_01001 One
+    When I log in
+
+  Scenario: TC_01002 Two
+    * I log out
+`,
+			want: []GherkinScenario{
+				{Name: "TC_01001 One", Line: 5, Steps: []GherkinStep{{Keyword: "Given", Text: "the login page"}, {Keyword: "When", Text: "I log in"}}},
+				{Name: "TC_01002 Two", Line: 8, Steps: []GherkinStep{{Keyword: "Given", Text: "the login page"}, {Keyword: "*", Text: "I log out"}}},
+			},
+		},
+		{
+			name: "doc strings and tables join the previous step; examples are skipped",
+			feature: `Feature: Login
+  Scenario Outline: TC_01001 Outline
+    Given the users
+      | name |
+      | ann  |
+    Then I see
+      """
+      Welcome
+      """
+
+    Examples:
+      | name |
+      | bob  |
+`,
+			want: []GherkinScenario{{
+				Name: "TC_01001 Outline",
+				Line: 2,
+				Steps: []GherkinStep{
+					{Keyword: "Given", Text: "the users\n| name |\n| ann  |"},
+					{Keyword: "Then", Text: "I see\nWelcome"},
+				},
+			}},
+		},
+		{
+			name: "unexpected line in a scenario",
+			feature: `Feature: Login
+  Scenario: TC_01001 One
+    Given the login page
+    I log in
+`,
+			wantErr: `line 4: unexpected "I log in"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFeature(tt.feature)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ParseFeature() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFeature() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFeature() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStepsFromScenario(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []GherkinStep
+		want  []models.TestStep
+	}{
+		{
+			name: "actions and expected results pair up",
+			steps: []GherkinStep{
+				{Keyword: "Given", Text: "the login page"},
+				{Keyword: "When", Text: "I log in"},
+				{Keyword: "Then", Text: "I see the dashboard"},
+				{Keyword: "And", Text: "I see my name"},
+				{Keyword: "When", Text: "I log out"},
+			},
+			want: []models.TestStep{
+				{Number: 1, Action: "the login page"},
+				{Number: 2, Action: "I log in", ExpectedResult: "I see the dashboard\nI see my name"},
+				{Number: 3, Action: "I log out"},
+			},
+		},
+		{
+			name: "And after an action continues it",
+			steps: []GherkinStep{
+				{Keyword: "Given", Text: "the login page"},
+				{Keyword: "And", Text: "a user"},
+				{Keyword: "But", Text: "no session"},
+			},
+			want: []models.TestStep{{Number: 1, Action: "the login page\na user\nno session"}},
+		},
+		{
+			name: "Then without an action",
+			steps: []GherkinStep{
+				{Keyword: "Then", Text: "the page loads"},
+				{Keyword: "Given", Text: "a user"},
+				{Keyword: "Then", Text: "a greeting"},
+				{Keyword: "Then", Text: "a menu"},
+			},
+			want: []models.TestStep{
+				{Number: 1, ExpectedResult: "the page loads"},
+				{Number: 2, Action: "a user", ExpectedResult: "a greeting\na menu"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stepsFromScenario(tt.steps); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("stepsFromScenario() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderFeatureRoundTrip(t *testing.T) {
+	steps := []models.TestStep{
+		{Number: 1, Action: "Open the login page"},
+		{Number: 2, Action: "Log in", ExpectedResult: "Dashboard shown"},
+		{Number: 3, Action: "Open settings", ExpectedResult: "Settings shown", Extra: map[string]string{"Keyword": "given"}},
+	}
+	tc := models.DetailedTestCaseResponse{
+		Title: "TC_01001 Login",
+		Tags:  []string{"smoke test"},
+		Tables: []models.TableWithData{
+			{BlockID: "environment"},
+			{BlockID: "steps", Steps: steps},
+			{BlockID: "other", Steps: []models.TestStep{{Number: 1, Action: "Not rendered"}}},
+		},
+		Errors: []models.TestCaseError{{Message: "failed to get table data:\nstatus 502"}},
+	}
+
+	feature := RenderFeature("Login", []models.DetailedTestCaseResponse{tc}, config.DefaultSchema())
+	if strings.Contains(feature, "Not rendered") {
+		t.Errorf("feature renders a table other than the step table:\n%s", feature)
+	}
+	if !strings.Contains(feature, "  # Incomplete: failed to get table data: status 502\n") {
+		t.Errorf("feature does not flag the read error:\n%s", feature)
+	}
+	if !strings.Contains(feature, "    Given Open settings\n") {
+		t.Errorf("feature ignores the Keyword column:\n%s", feature)
+	}
+
+	scenarios, err := ParseFeature(feature)
+	if err != nil {
+		t.Fatalf("ParseFeature() error = %v", err)
+	}
+	if len(scenarios) != 1 {
+		t.Fatalf("scenarios = %d, want 1", len(scenarios))
+	}
+	if want := []string{"TC_01001", "smoke_test"}; !reflect.DeepEqual(scenarios[0].Tags, want) {
+		t.Errorf("tags = %q, want %q", scenarios[0].Tags, want)
+	}
+	if scenarios[0].Name != tc.Title {
+		t.Errorf("name = %q, want %q", scenarios[0].Name, tc.Title)
+	}
+
+	want := []models.TestStep{
+		{Number: 1, Action: "Open the login page"},
+		{Number: 2, Action: "Log in", ExpectedResult: "Dashboard shown"},
+		{Number: 3, Action: "Open settings", ExpectedResult: "Settings shown"},
+	}
+	if got := stepsFromScenario(scenarios[0].Steps); !reflect.DeepEqual(got, want) {
+		t.Errorf("steps = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,408 @@
+package services
+
+import (
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"demo-notion-api/store"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrHistoryNotFound     = errors.New("no history for test case")
+	ErrVersionNotFound     = errors.New("version not found")
+	ErrInvalidVersionRange = errors.New("invalid version range")
+)
+
+// columnAssignees names assignee changes in history diffs
+const columnAssignees = "Assignees"
+
+// historyStepColumns compares every step field in history diffs
+var historyStepColumns = importColumns{
+	ColumnAction:         true,
+	ColumnExpectedResult: true,
+	ColumnActualResult:   true,
+	ColumnStepStatus:     true,
+	ColumnScreenshot:     true,
+}
+
+// HistoryService keeps the versions of every test case in one file per test
+// case under DATA_DIR/history/<project>
+type HistoryService struct {
+	dir      string
+	mu       sync.RWMutex
+	versions map[string]map[string][]models.TestCaseVersion
+}
+
+// NewHistoryService loads the versions recorded by earlier runs
+func NewHistoryService(cfg *config.Config) (*HistoryService, error) {
+	s := &HistoryService{
+		dir:      filepath.Join(cfg.DataDir, "history"),
+		versions: make(map[string]map[string][]models.TestCaseVersion),
+	}
+
+	files, err := filepath.Glob(filepath.Join(s.dir, "*", "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history files: %w", err)
+	}
+
+	for _, path := range files {
+		var versions []models.TestCaseVersion
+		if err := store.NewJSONFile(path).Load(&versions); err != nil {
+			return nil, err
+		}
+		if len(versions) == 0 {
+			continue
+		}
+
+		project, err := url.PathUnescape(filepath.Base(filepath.Dir(path)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid history directory %s: %w", path, err)
+		}
+		if s.versions[project] == nil {
+			s.versions[project] = make(map[string][]models.TestCaseVersion)
+		}
+		s.versions[project][versions[0].TestCase.TestCaseKey] = versions
+	}
+
+	return s, nil
+}
+
+// HasVersion reports whether the latest version of a test case is at least as
+// recent as its current last edited time
+func (s *HistoryService) HasVersion(project string, tc models.TestCaseResponse) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions := s.versions[project][tc.TestCaseKey]
+	return len(versions) > 0 && !tc.LastEdited.After(versions[len(versions)-1].LastEdited)
+}
+
+// Record stores version as the next version of its test case. Versions that are
+// not newer than the latest one are ignored; the result reports whether it was stored.
+func (s *HistoryService) Record(project string, version models.TestCaseVersion) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := version.TestCase.TestCaseKey
+	if s.versions[project] == nil {
+		s.versions[project] = make(map[string][]models.TestCaseVersion)
+	}
+
+	versions := s.versions[project][key]
+	if len(versions) > 0 && !version.LastEdited.After(versions[len(versions)-1].LastEdited) {
+		return false, nil
+	}
+
+	version.Version = len(versions) + 1
+	updated := append(versions, version)
+	if err := s.file(project, key).Save(updated); err != nil {
+		return false, err
+	}
+
+	s.versions[project][key] = updated
+	return true, nil
+}
+
+// LatestVersions returns the latest version of every test case of every
+// project, ordered by project then key
+func (s *HistoryService) LatestVersions() []models.TestCaseVersion {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var latest []models.TestCaseVersion
+	for _, testCases := range s.versions {
+		for _, versions := range testCases {
+			latest = append(latest, versions[len(versions)-1])
+		}
+	}
+
+	sort.Slice(latest, func(i, j int) bool {
+		if latest[i].TestCase.Project != latest[j].TestCase.Project {
+			return latest[i].TestCase.Project < latest[j].TestCase.Project
+		}
+		return compareKeys(latest[i].TestCase.TestCaseKey, latest[j].TestCase.TestCaseKey) < 0
+	})
+	return latest
+}
+
+// History lists the versions of a test case, oldest first
+func (s *HistoryService) History(project, key string) (*models.HistoryResponse, error) {
+	versions, err := s.testCaseVersions(project, key)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &models.HistoryResponse{
+		Project:     project,
+		TestCaseKey: key,
+		Versions:    make([]models.VersionSummary, 0, len(versions)),
+	}
+	for _, version := range versions {
+		history.Versions = append(history.Versions, versionSummary(version))
+	}
+	return history, nil
+}
+
+// Version returns one version of a test case with its content
+func (s *HistoryService) Version(project, key string, number int) (*models.TestCaseVersion, error) {
+	versions, err := s.testCaseVersions(project, key)
+	if err != nil {
+		return nil, err
+	}
+	if number < 1 || number > len(versions) {
+		return nil, fmt.Errorf("%w: %s version %d", ErrVersionNotFound, key, number)
+	}
+	return &versions[number-1], nil
+}
+
+// Diff compares two versions of a test case and attributes every change to
+// the latest version between them that made it
+func (s *HistoryService) Diff(project, key string, from, to int) (*models.VersionDiff, error) {
+	versions, err := s.testCaseVersions(project, key)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("%w: version %d is after version %d", ErrInvalidVersionRange, from, to)
+	}
+	for _, number := range []int{from, to} {
+		if number < 1 || number > len(versions) {
+			return nil, fmt.Errorf("%w: %s version %d", ErrVersionNotFound, key, number)
+		}
+	}
+
+	span := versions[from-1 : to]
+	return &models.VersionDiff{
+		Project:     project,
+		TestCaseKey: key,
+		From:        versionSummary(span[0]),
+		To:          versionSummary(span[len(span)-1]),
+		Properties:  propertyChanges(span),
+		Steps:       historyStepChanges(span),
+		Blocks:      blockChanges(span),
+	}, nil
+}
+
+// Helper methods
+
+func (s *HistoryService) testCaseVersions(project, key string) ([]models.TestCaseVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions := s.versions[project][key]
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrHistoryNotFound, key)
+	}
+	return versions, nil
+}
+
+func (s *HistoryService) file(project, key string) *store.JSONFile {
+	return store.NewJSONFile(filepath.Join(s.dir, url.PathEscape(project), url.PathEscape(key)+".json"))
+}
+
+// Helper functions
+
+func versionSummary(version models.TestCaseVersion) models.VersionSummary {
+	return models.VersionSummary{
+		Version:      version.Version,
+		Title:        version.TestCase.Title,
+		Status:       version.TestCase.Status,
+		LastEdited:   version.LastEdited,
+		LastEditedBy: version.LastEditedBy,
+		SyncedAt:     version.SyncedAt,
+	}
+}
+
+// attribution returns the latest version of span in which value changed,
+// or the last version when it never changed in between
+func attribution(span []models.TestCaseVersion, value func(models.TestCaseVersion) string) models.Attribution {
+	changed := span[len(span)-1]
+	for k := len(span) - 1; k > 0; k-- {
+		if value(span[k]) != value(span[k-1]) {
+			changed = span[k]
+			break
+		}
+	}
+	return models.Attribution{
+		Version:   changed.Version,
+		ChangedBy: changed.LastEditedBy,
+		ChangedAt: changed.LastEdited,
+	}
+}
+
+func propertyChanges(span []models.TestCaseVersion) []models.HistoryChange {
+	properties := []struct {
+		field string
+		value func(models.TestCaseVersion) string
+	}{
+		{ColumnTitle, func(v models.TestCaseVersion) string { return v.TestCase.Title }},
+		{ColumnStatus, func(v models.TestCaseVersion) string { return v.TestCase.Status }},
+		{ColumnTestDate, func(v models.TestCaseVersion) string { return v.TestCase.TestDate }},
+		{ColumnTags, func(v models.TestCaseVersion) string { return strings.Join(v.TestCase.Tags, ", ") }},
+		{columnAssignees, func(v models.TestCaseVersion) string { return strings.Join(v.TestCase.Assignees, ", ") }},
+	}
+
+	changes := []models.HistoryChange{}
+	first, last := span[0], span[len(span)-1]
+	for _, property := range properties {
+		old, new := property.value(first), property.value(last)
+		if old == new {
+			continue
+		}
+		changes = append(changes, models.HistoryChange{
+			Field:       property.field,
+			Old:         old,
+			New:         new,
+			Attribution: attribution(span, property.value),
+		})
+	}
+	return changes
+}
+
+func historyStepChanges(span []models.TestCaseVersion) []models.HistoryStepChange {
+	oldSteps := versionSteps(span[0])
+	newSteps := versionSteps(span[len(span)-1])
+
+	var numbers []int
+	for number := range oldSteps {
+		numbers = append(numbers, number)
+	}
+	for number := range newSteps {
+		if _, ok := oldSteps[number]; !ok {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+
+	changes := []models.HistoryStepChange{}
+	for _, number := range numbers {
+		presence := func(v models.TestCaseVersion) string {
+			_, ok := versionSteps(v)[number]
+			return fmt.Sprint(ok)
+		}
+
+		oldStep, inOld := oldSteps[number]
+		newStep, inNew := newSteps[number]
+		switch {
+		case !inOld:
+			changes = append(changes, models.HistoryStepChange{Number: number, Change: StepAdded, Attribution: attribution(span, presence)})
+		case !inNew:
+			changes = append(changes, models.HistoryStepChange{Number: number, Change: StepRemoved, Attribution: attribution(span, presence)})
+		default:
+			fields := stepFieldChanges(oldStep, newStep, historyStepColumns)
+			if len(fields) == 0 {
+				continue
+			}
+
+			change := models.HistoryStepChange{Number: number, Change: StepModified}
+			for _, field := range fields {
+				column := field.Field
+				fieldAttribution := attribution(span, func(v models.TestCaseVersion) string {
+					return presence(v) + stepValue(versionSteps(v)[number], column)
+				})
+				change.Fields = append(change.Fields, models.HistoryChange{
+					Field:       field.Field,
+					Old:         field.Old,
+					New:         field.New,
+					Attribution: fieldAttribution,
+				})
+				if fieldAttribution.Version > change.Version {
+					change.Attribution = fieldAttribution
+				}
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func blockChanges(span []models.TestCaseVersion) []models.HistoryBlockChange {
+	oldBlocks := textBlocks(span[0].Blocks)
+	newBlocks := textBlocks(span[len(span)-1].Blocks)
+
+	var ids []string
+	for _, block := range oldBlocks {
+		ids = append(ids, block.BlockID)
+	}
+	for _, block := range newBlocks {
+		if _, ok := findTextBlock(oldBlocks, block.BlockID); !ok {
+			ids = append(ids, block.BlockID)
+		}
+	}
+
+	changes := []models.HistoryBlockChange{}
+	for _, id := range ids {
+		content := func(v models.TestCaseVersion) string {
+			block, ok := findTextBlock(textBlocks(v.Blocks), id)
+			return fmt.Sprint(ok) + block.Content
+		}
+
+		oldBlock, inOld := findTextBlock(oldBlocks, id)
+		newBlock, inNew := findTextBlock(newBlocks, id)
+		change := models.HistoryBlockChange{BlockID: id, Old: oldBlock.Content, New: newBlock.Content}
+		switch {
+		case !inOld:
+			change.Type, change.Change = newBlock.Type, StepAdded
+		case !inNew:
+			change.Type, change.Change = oldBlock.Type, StepRemoved
+		case oldBlock.Content != newBlock.Content:
+			change.Type, change.Change = newBlock.Type, StepModified
+		default:
+			continue
+		}
+		change.Attribution = attribution(span, content)
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func versionSteps(version models.TestCaseVersion) map[int]models.TestStep {
+	table := stepTable(version.TestCase)
+	if table == nil {
+		return map[int]models.TestStep{}
+	}
+	return stepsByNumber(table.Steps)
+}
+
+func stepValue(step models.TestStep, column string) string {
+	switch column {
+	case ColumnAction:
+		return step.Action
+	case ColumnExpectedResult:
+		return step.ExpectedResult
+	case ColumnActualResult:
+		return step.ActualResult
+	case ColumnStepStatus:
+		return step.Status
+	case ColumnScreenshot:
+		return step.Screenshot
+	}
+	return ""
+}
+
+// textBlocks flattens a block tree into its blocks other than tables
+func textBlocks(blocks []models.BlockResponse) []models.BlockResponse {
+	var flat []models.BlockResponse
+	for _, block := range blocks {
+		if block.Type != "table" {
+			flat = append(flat, block)
+		}
+		flat = append(flat, textBlocks(block.Children)...)
+	}
+	return flat
+}
+
+func findTextBlock(blocks []models.BlockResponse, id string) (models.BlockResponse, bool) {
+	for _, block := range blocks {
+		if block.BlockID == id {
+			return block, true
+		}
+	}
+	return models.BlockResponse{}, false
+}
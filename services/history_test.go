@@ -0,0 +1,209 @@
+package services
+
+import (
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// historyVersion builds a version edited by editor on day of January 2026
+func historyVersion(day int, editor, title, status string, steps []models.TestStep, blocks ...models.BlockResponse) models.TestCaseVersion {
+	return models.TestCaseVersion{
+		LastEdited:   time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC),
+		LastEditedBy: editor,
+		TestCase: models.DetailedTestCaseResponse{
+			TestCaseKey: "TC_01001",
+			Title:       title,
+			Status:      status,
+			Tables:      []models.TableWithData{{BlockID: "table", Steps: steps}},
+		},
+		Blocks: blocks,
+	}
+}
+
+// newTestHistory records three versions of TC_01001 by ann, bob and cid
+func newTestHistory(t *testing.T) *HistoryService {
+	t.Helper()
+
+	history, err := NewHistoryService(&config.Config{DataDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paragraph := func(id, content string) models.BlockResponse {
+		return models.BlockResponse{BlockID: id, Type: "paragraph", Content: content}
+	}
+	toggle := func(children ...models.BlockResponse) models.BlockResponse {
+		return models.BlockResponse{BlockID: "toggle", Type: "toggle", Content: "Details", HasChildren: true, Children: children}
+	}
+	table := models.BlockResponse{BlockID: "table", Type: "table"}
+
+	versions := []models.TestCaseVersion{
+		historyVersion(1, "ann", "TC_01001 Login", "Not run",
+			[]models.TestStep{{Number: 1, Action: "Open"}, {Number: 2, Action: "Log in", ExpectedResult: "Dashboard"}},
+			paragraph("intro", "Intro"), toggle(paragraph("notes", "Notes")), table),
+		historyVersion(2, "bob", "TC_01001 Log in", "Passed",
+			[]models.TestStep{{Number: 1, Action: "Open"}, {Number: 2, Action: "Log in", ExpectedResult: "Dashboard shown"}},
+			paragraph("intro", "Intro, revised"), toggle(paragraph("notes", "Notes")), table),
+		historyVersion(3, "cid", "TC_01001 Login", "Passed",
+			[]models.TestStep{{Number: 2, Action: "Log in", ExpectedResult: "Dashboard shown"}, {Number: 3, Action: "Log out"}},
+			paragraph("intro", "Intro, revised"), toggle(paragraph("summary", "Summary")), table),
+	}
+	for _, version := range versions {
+		if stored, err := history.Record("demo", version); err != nil || !stored {
+			t.Fatalf("Record() = %v, %v, want stored", stored, err)
+		}
+	}
+	return history
+}
+
+func TestHistoryDiff(t *testing.T) {
+	history := newTestHistory(t)
+
+	by := func(version int, editor string) models.Attribution {
+		return models.Attribution{Version: version, ChangedBy: editor, ChangedAt: time.Date(2026, 1, version, 0, 0, 0, 0, time.UTC)}
+	}
+
+	tests := []struct {
+		name           string
+		from, to       int
+		wantProperties []models.HistoryChange
+		wantSteps      []models.HistoryStepChange
+		wantBlocks     []models.HistoryBlockChange
+	}{
+		{
+			name: "whole history",
+			from: 1, to: 3,
+			wantProperties: []models.HistoryChange{
+				{Field: ColumnStatus, Old: "Not run", New: "Passed", Attribution: by(2, "bob")},
+			},
+			wantSteps: []models.HistoryStepChange{
+				{Number: 1, Change: StepRemoved, Attribution: by(3, "cid")},
+				{Number: 2, Change: StepModified, Attribution: by(2, "bob"), Fields: []models.HistoryChange{
+					{Field: ColumnExpectedResult, Old: "Dashboard", New: "Dashboard shown", Attribution: by(2, "bob")},
+				}},
+				{Number: 3, Change: StepAdded, Attribution: by(3, "cid")},
+			},
+			wantBlocks: []models.HistoryBlockChange{
+				{BlockID: "intro", Type: "paragraph", Change: StepModified, Old: "Intro", New: "Intro, revised", Attribution: by(2, "bob")},
+				{BlockID: "notes", Type: "paragraph", Change: StepRemoved, Old: "Notes", Attribution: by(3, "cid")},
+				{BlockID: "summary", Type: "paragraph", Change: StepAdded, New: "Summary", Attribution: by(3, "cid")},
+			},
+		},
+		{
+			name: "reverted title",
+			from: 2, to: 3,
+			wantProperties: []models.HistoryChange{
+				{Field: ColumnTitle, Old: "TC_01001 Log in", New: "TC_01001 Login", Attribution: by(3, "cid")},
+			},
+			wantSteps: []models.HistoryStepChange{
+				{Number: 1, Change: StepRemoved, Attribution: by(3, "cid")},
+				{Number: 3, Change: StepAdded, Attribution: by(3, "cid")},
+			},
+			wantBlocks: []models.HistoryBlockChange{
+				{BlockID: "notes", Type: "paragraph", Change: StepRemoved, Old: "Notes", Attribution: by(3, "cid")},
+				{BlockID: "summary", Type: "paragraph", Change: StepAdded, New: "Summary", Attribution: by(3, "cid")},
+			},
+		},
+		{
+			name: "same version",
+			from: 2, to: 2,
+			wantProperties: []models.HistoryChange{},
+			wantSteps:      []models.HistoryStepChange{},
+			wantBlocks:     []models.HistoryBlockChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := history.Diff("demo", "TC_01001", tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if diff.From.Version != tt.from || diff.To.Version != tt.to {
+				t.Errorf("versions = %d..%d, want %d..%d", diff.From.Version, diff.To.Version, tt.from, tt.to)
+			}
+			if !reflect.DeepEqual(diff.Properties, tt.wantProperties) {
+				t.Errorf("properties = %+v, want %+v", diff.Properties, tt.wantProperties)
+			}
+			if !reflect.DeepEqual(diff.Steps, tt.wantSteps) {
+				t.Errorf("steps = %+v, want %+v", diff.Steps, tt.wantSteps)
+			}
+			if !reflect.DeepEqual(diff.Blocks, tt.wantBlocks) {
+				t.Errorf("blocks = %+v, want %+v", diff.Blocks, tt.wantBlocks)
+			}
+		})
+	}
+}
+
+func TestHistoryDiffErrors(t *testing.T) {
+	history := newTestHistory(t)
+
+	tests := []struct {
+		name     string
+		key      string
+		from, to int
+		want     error
+	}{
+		{name: "reversed range", key: "TC_01001", from: 3, to: 1, want: ErrInvalidVersionRange},
+		{name: "version zero", key: "TC_01001", from: 0, to: 2, want: ErrVersionNotFound},
+		{name: "version after the latest", key: "TC_01001", from: 1, to: 4, want: ErrVersionNotFound},
+		{name: "unknown test case", key: "TC_09999", from: 1, to: 1, want: ErrHistoryNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := history.Diff("demo", tt.key, tt.from, tt.to); !errors.Is(err, tt.want) {
+				t.Errorf("Diff() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryRecord(t *testing.T) {
+	dir := t.TempDir()
+	history, err := NewHistoryService(&config.Config{DataDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		day         int
+		wantStored  bool
+		wantVersion int
+	}{
+		{name: "first version", day: 2, wantStored: true, wantVersion: 1},
+		{name: "same last edited time", day: 2, wantStored: false, wantVersion: 1},
+		{name: "older edit", day: 1, wantStored: false, wantVersion: 1},
+		{name: "newer edit", day: 3, wantStored: true, wantVersion: 2},
+	}
+
+	for _, tt := range tests {
+		stored, err := history.Record("demo", historyVersion(tt.day, "ann", "TC_01001 Login", "Passed", nil))
+		if err != nil {
+			t.Fatalf("%s: Record() error = %v", tt.name, err)
+		}
+		if stored != tt.wantStored {
+			t.Errorf("%s: stored = %v, want %v", tt.name, stored, tt.wantStored)
+		}
+		if latest := history.LatestVersions(); len(latest) != 1 || latest[0].Version != tt.wantVersion {
+			t.Errorf("%s: latest = %+v, want version %d", tt.name, latest, tt.wantVersion)
+		}
+	}
+
+	reloaded, err := NewHistoryService(&config.Config{DataDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions, err := reloaded.History("demo", "TC_01001")
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(versions.Versions) != 2 {
+		t.Errorf("reloaded versions = %d, want 2", len(versions.Versions))
+	}
+}
@@ -0,0 +1,536 @@
+package services
+
+import (
+	"context"
+	"demo-notion-api/models"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Change kinds reported in models.StepChange and the history diffs
+const (
+	StepAdded    = "added"
+	StepRemoved  = "removed"
+	StepModified = "modified"
+)
+
+// SpreadsheetSheet is a named sheet of raw rows, header row first
+type SpreadsheetSheet struct {
+	Name string
+	Rows [][]string
+}
+
+type ImportService struct {
+	notionService *NotionService
+}
+
+func NewImportService(notionService *NotionService) *ImportService {
+	return &ImportService{
+		notionService: notionService,
+	}
+}
+
+// WithContext returns a copy of the service whose Notion calls carry ctx
+func (s *ImportService) WithContext(ctx context.Context) *ImportService {
+	return &ImportService{notionService: s.notionService.WithContext(ctx)}
+}
+
+// ImportOptions control how an import is applied
+type ImportOptions struct {
+	// DryRun computes the diff without writing to Notion
+	DryRun bool
+	// DeleteMissing removes existing steps whose number is not imported.
+	// Without it, steps are only added and modified.
+	DeleteMissing bool
+}
+
+// importedTestCase is a test case assembled from the rows sharing a key
+type importedTestCase struct {
+	Key      string
+	Title    string
+	Status   string
+	TestDate string
+	Tags     []string
+	Steps    []models.TestStep
+	// HasSteps is set when a row of the test case carried step data; the steps
+	// of test cases without any are left untouched
+	HasSteps bool
+}
+
+// importColumns records which spreadsheet columns were present, so that
+// missing columns leave the matching Notion values untouched
+type importColumns map[string]bool
+
+// ReadSpreadsheet reads a CSV or XLSX file into sheets of raw rows
+func ReadSpreadsheet(r io.Reader, format string) ([]SpreadsheetSheet, error) {
+	switch format {
+	case "csv":
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+		return []SpreadsheetSheet{{Name: "csv", Rows: rows}}, nil
+
+	case "xlsx":
+		f, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read xlsx: %w", err)
+		}
+		defer f.Close()
+
+		var sheets []SpreadsheetSheet
+		for _, name := range f.GetSheetList() {
+			rows, err := f.GetRows(name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read sheet %s: %w", name, err)
+			}
+			sheets = append(sheets, SpreadsheetSheet{Name: name, Rows: rows})
+		}
+		return sheets, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// Import diffs the spreadsheet against the test cases in Notion and, unless
+// opts.DryRun is set, creates missing test cases and updates changed ones.
+// Rows of other projects are rejected.
+func (s *ImportService) Import(sheets []SpreadsheetSheet, opts ImportOptions) (*models.ImportResult, error) {
+	imported, columns, errs := groupImportRows(sheets, s.notionService.config.ProjectName)
+	return s.importTestCases(imported, columns, errs, opts)
+}
+
+// Helper methods
+
+// importTestCases diffs imported test cases against Notion and applies the changes unless opts.DryRun is set.
+// Only the fields whose column is present in columns are compared and written.
+func (s *ImportService) importTestCases(imported []importedTestCase, columns importColumns, errs []models.ImportError, opts ImportOptions) (*models.ImportResult, error) {
+	dryRun := opts.DryRun
+	existing, err := s.notionService.GetDetailedTestCases()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load existing test cases: %w", err)
+	}
+	byKey := make(map[string]models.DetailedTestCaseResponse)
+	for _, tc := range existing {
+		byKey[tc.TestCaseKey] = tc
+	}
+
+	result := &models.ImportResult{
+		DryRun:    dryRun,
+		Created:   []models.ImportChange{},
+		Updated:   []models.ImportChange{},
+		Unchanged: []string{},
+		Errors:    errs,
+	}
+
+	for _, tc := range imported {
+		current, exists := byKey[tc.Key]
+		if !exists {
+			if err := s.validateNew(tc); err != nil {
+				result.Errors = append(result.Errors, models.ImportError{TestCaseKey: tc.Key, Message: err.Error()})
+				continue
+			}
+
+			change := createChange(tc, columns)
+			if !dryRun {
+				page, err := s.notionService.CreateTestCase(importUpdate(tc, change.Fields), tc.Steps)
+				if err != nil {
+					result.Errors = append(result.Errors, models.ImportError{TestCaseKey: tc.Key, Message: err.Error()})
+					continue
+				}
+				change.PageID = page.ID
+			}
+			result.Created = append(result.Created, change)
+			continue
+		}
+
+		// Steps missing from a partly read test case would be added again
+		if len(current.Errors) > 0 {
+			result.Errors = append(result.Errors, models.ImportError{TestCaseKey: tc.Key, Message: "could not read the test case from Notion: " + current.Errors[0].Message})
+			continue
+		}
+
+		change, update := diffTestCase(tc, current, columns, opts.DeleteMissing)
+		if len(change.Fields) == 0 && len(change.Steps) == 0 {
+			result.Unchanged = append(result.Unchanged, tc.Key)
+			continue
+		}
+
+		if !dryRun {
+			if err := s.applyUpdate(tc, current, update, change.Steps, columns); err != nil {
+				result.Errors = append(result.Errors, models.ImportError{TestCaseKey: tc.Key, Message: err.Error()})
+				continue
+			}
+		}
+		result.Updated = append(result.Updated, change)
+	}
+
+	return result, nil
+}
+
+func (s *ImportService) validateNew(tc importedTestCase) error {
+	if tc.Title == "" {
+		return fmt.Errorf("title is required to create test case %s", tc.Key)
+	}
+	if key := s.notionService.Schema().ExtractKey(tc.Title); key != tc.Key {
+		return fmt.Errorf("title %q does not carry key %s", tc.Title, tc.Key)
+	}
+	return nil
+}
+
+func (s *ImportService) applyUpdate(tc importedTestCase, current models.DetailedTestCaseResponse, update models.TestCaseUpdate, stepChanges []models.StepChange, columns importColumns) error {
+	if err := s.notionService.UpdateTestCase(current.PageID, update); err != nil {
+		return err
+	}
+	if len(stepChanges) == 0 {
+		return nil
+	}
+
+	table := stepTable(current)
+	if table == nil {
+		// No step table yet: append a new one with all imported steps
+		var rows [][]string
+		for _, step := range tc.Steps {
+			rows = append(rows, StepCells(step, DefaultStepOrder()))
+		}
+		header := s.notionService.StepTableHeader()
+		return s.notionService.AppendBlockChildren(current.PageID, []interface{}{TablePayload(header, rows)})
+	}
+
+	tableColumns := s.tableColumns(*table)
+	currentSteps := stepsByNumber(table.Steps)
+	importedSteps := stepsByNumber(tc.Steps)
+
+	for _, change := range stepChanges {
+		switch change.Change {
+		case StepModified:
+			old := currentSteps[change.Number]
+			cells := mergeStepCells(rowCells(*table, old.BlockID), importedSteps[change.Number], tableColumns, columns)
+			if err := s.notionService.UpdateTableRow(old.BlockID, cells); err != nil {
+				return fmt.Errorf("failed to update step %d: %w", change.Number, err)
+			}
+		case StepAdded:
+			cells := StepCells(importedSteps[change.Number], tableColumns)
+			if err := s.notionService.AppendBlockChildren(table.BlockID, []interface{}{tableRowPayload(cells)}); err != nil {
+				return fmt.Errorf("failed to add step %d: %w", change.Number, err)
+			}
+		case StepRemoved:
+			if err := s.notionService.DeleteBlock(currentSteps[change.Number].BlockID); err != nil {
+				return fmt.Errorf("failed to remove step %d: %w", change.Number, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// tableColumns returns the step field of each column of an existing table
+func (s *ImportService) tableColumns(table models.TableWithData) []string {
+	if table.HasColumnHeader && len(table.Rows) > 0 {
+		return mapStepColumns(table.Rows[0].Cells, s.notionService.stepColumns)
+	}
+
+	columns := make([]string, table.TableWidth)
+	copy(columns, defaultStepOrder)
+	return columns
+}
+
+// groupImportRows groups spreadsheet rows by test case key, in order of first
+// appearance. Rows whose Project cell names another project than project are rejected.
+func groupImportRows(sheets []SpreadsheetSheet, project string) ([]importedTestCase, importColumns, []models.ImportError) {
+	var imported []importedTestCase
+	index := make(map[string]int)
+	columns := make(importColumns)
+	var errs []models.ImportError
+
+	for _, sheet := range sheets {
+		if len(sheet.Rows) == 0 {
+			continue
+		}
+
+		header := make(map[string]int)
+		for i, name := range sheet.Rows[0] {
+			header[strings.TrimSpace(name)] = i
+			columns[strings.TrimSpace(name)] = true
+		}
+		if _, ok := header[ColumnTestCaseKey]; !ok {
+			errs = append(errs, models.ImportError{Sheet: sheet.Name, Row: 1, Message: fmt.Sprintf("missing %q column", ColumnTestCaseKey)})
+			continue
+		}
+
+		// Rows with a blank step cell continue from the previous step of
+		// their test case on the sheet, as in ParseSteps
+		previous := make(map[string]int)
+		for r := 1; r < len(sheet.Rows); r++ {
+			row := sheet.Rows[r]
+			cell := func(column string) string {
+				if i, ok := header[column]; ok && i < len(row) {
+					return strings.TrimSpace(unescapeCell(row[i]))
+				}
+				return ""
+			}
+
+			key := cell(ColumnTestCaseKey)
+			if key == "" {
+				if !isBlankRow(row) {
+					errs = append(errs, models.ImportError{Sheet: sheet.Name, Row: r + 1, Message: "row has no test case key"})
+				}
+				continue
+			}
+			if rowProject := cell(ColumnProject); rowProject != "" && rowProject != project {
+				errs = append(errs, models.ImportError{Sheet: sheet.Name, Row: r + 1, TestCaseKey: key, Message: fmt.Sprintf("row belongs to project %q, not %q; import it into that project", rowProject, project)})
+				continue
+			}
+
+			rowCase := importedTestCase{
+				Key:      key,
+				Title:    cell(ColumnTitle),
+				Status:   cell(ColumnStatus),
+				TestDate: cell(ColumnTestDate),
+				Tags:     splitTags(cell(ColumnTags)),
+			}
+
+			i, seen := index[key]
+			if !seen {
+				index[key] = len(imported)
+				imported = append(imported, rowCase)
+				i = len(imported) - 1
+			} else if msg := conflictingFields(imported[i], rowCase); msg != "" {
+				errs = append(errs, models.ImportError{Sheet: sheet.Name, Row: r + 1, TestCaseKey: key, Message: msg})
+				continue
+			}
+
+			numberCell := cell(ColumnStep)
+			if numberCell == "" && cell(ColumnAction) == "" && cell(ColumnExpectedResult) == "" {
+				continue
+			}
+			imported[i].HasSteps = true
+			number := previous[key] + 1
+			if numberCell != "" {
+				var err error
+				if number, err = strconv.Atoi(numberCell); err != nil {
+					errs = append(errs, models.ImportError{Sheet: sheet.Name, Row: r + 1, TestCaseKey: key, Message: fmt.Sprintf("step number %q is not a number", numberCell)})
+					continue
+				}
+			}
+			previous[key] = number
+
+			step := models.TestStep{
+				Number:         number,
+				Action:         cell(ColumnAction),
+				ExpectedResult: cell(ColumnExpectedResult),
+				ActualResult:   cell(ColumnActualResult),
+				Status:         cell(ColumnStepStatus),
+				Screenshot:     cell(ColumnScreenshot),
+			}
+
+			duplicate := false
+			for _, existing := range imported[i].Steps {
+				if existing.Number != number {
+					continue
+				}
+				duplicate = true
+				if !reflect.DeepEqual(existing, step) {
+					errs = append(errs, models.ImportError{Sheet: sheet.Name, Row: r + 1, TestCaseKey: key, Message: fmt.Sprintf("step %d is defined twice with different content", number)})
+				}
+			}
+			if !duplicate {
+				imported[i].Steps = append(imported[i].Steps, step)
+			}
+		}
+	}
+
+	return imported, columns, errs
+}
+
+func conflictingFields(a, b importedTestCase) string {
+	switch {
+	case a.Title != b.Title:
+		return fmt.Sprintf("title %q conflicts with %q", b.Title, a.Title)
+	case a.Status != b.Status:
+		return fmt.Sprintf("status %q conflicts with %q", b.Status, a.Status)
+	case a.TestDate != b.TestDate:
+		return fmt.Sprintf("test date %q conflicts with %q", b.TestDate, a.TestDate)
+	case strings.Join(a.Tags, ",") != strings.Join(b.Tags, ","):
+		return "tags conflict with an earlier row"
+	}
+	return ""
+}
+
+func createChange(tc importedTestCase, columns importColumns) models.ImportChange {
+	change := models.ImportChange{TestCaseKey: tc.Key, Title: tc.Title}
+	change.Fields = fieldChanges(tc, models.DetailedTestCaseResponse{}, columns)
+	for _, step := range tc.Steps {
+		change.Steps = append(change.Steps, models.StepChange{Number: step.Number, Change: StepAdded})
+	}
+	return change
+}
+
+// diffTestCase compares an imported test case with Notion and returns the
+// change to report and the property update to apply. Steps are only compared
+// when the test case carried step rows, and existing steps missing from them
+// are only removed with deleteMissing.
+func diffTestCase(tc importedTestCase, current models.DetailedTestCaseResponse, columns importColumns, deleteMissing bool) (models.ImportChange, models.TestCaseUpdate) {
+	change := models.ImportChange{TestCaseKey: tc.Key, PageID: current.PageID, Title: current.Title}
+	change.Fields = fieldChanges(tc, current, columns)
+	update := importUpdate(tc, change.Fields)
+
+	if !columns[ColumnStep] || !tc.HasSteps {
+		return change, update
+	}
+
+	var currentSteps []models.TestStep
+	if table := stepTable(current); table != nil {
+		currentSteps = table.Steps
+	}
+	currentByNumber := stepsByNumber(currentSteps)
+	importedByNumber := stepsByNumber(tc.Steps)
+
+	for _, step := range tc.Steps {
+		old, exists := currentByNumber[step.Number]
+		if !exists {
+			change.Steps = append(change.Steps, models.StepChange{Number: step.Number, Change: StepAdded})
+			continue
+		}
+		if fields := stepFieldChanges(old, step, columns); len(fields) > 0 {
+			change.Steps = append(change.Steps, models.StepChange{Number: step.Number, Change: StepModified, Fields: fields})
+		}
+	}
+	if !deleteMissing {
+		return change, update
+	}
+	for _, step := range currentSteps {
+		if _, kept := importedByNumber[step.Number]; !kept {
+			change.Steps = append(change.Steps, models.StepChange{Number: step.Number, Change: StepRemoved})
+		}
+	}
+
+	return change, update
+}
+
+func fieldChanges(tc importedTestCase, current models.DetailedTestCaseResponse, columns importColumns) []models.FieldChange {
+	var fields []models.FieldChange
+	compare := func(column, old, new string) {
+		if columns[column] && old != new {
+			fields = append(fields, models.FieldChange{Field: column, Old: old, New: new})
+		}
+	}
+
+	compare(ColumnTitle, current.Title, tc.Title)
+	compare(ColumnStatus, current.Status, tc.Status)
+	compare(ColumnTestDate, current.TestDate, tc.TestDate)
+	compare(ColumnTags, strings.Join(current.Tags, ", "), strings.Join(tc.Tags, ", "))
+	return fields
+}
+
+func stepFieldChanges(old, new models.TestStep, columns importColumns) []models.FieldChange {
+	var fields []models.FieldChange
+	compare := func(column, oldValue, newValue string) {
+		if columns[column] && oldValue != newValue {
+			fields = append(fields, models.FieldChange{Field: column, Old: oldValue, New: newValue})
+		}
+	}
+
+	compare(ColumnAction, old.Action, new.Action)
+	compare(ColumnExpectedResult, old.ExpectedResult, new.ExpectedResult)
+	compare(ColumnActualResult, old.ActualResult, new.ActualResult)
+	compare(ColumnStepStatus, old.Status, new.Status)
+	compare(ColumnScreenshot, old.Screenshot, new.Screenshot)
+	return fields
+}
+
+// importUpdate builds the property update for the given field changes
+func importUpdate(tc importedTestCase, changes []models.FieldChange) models.TestCaseUpdate {
+	changed := func(column string) bool {
+		for _, change := range changes {
+			if change.Field == column {
+				return true
+			}
+		}
+		return false
+	}
+
+	var update models.TestCaseUpdate
+	if changed(ColumnTitle) {
+		update.Title = &tc.Title
+	}
+	if changed(ColumnStatus) {
+		update.Status = &tc.Status
+	}
+	if changed(ColumnTestDate) {
+		update.TestDate = &tc.TestDate
+	}
+	if changed(ColumnTags) {
+		tags := append([]string{}, tc.Tags...)
+		update.Tags = &tags
+	}
+	return update
+}
+
+// stepTable returns the first table of a test case that holds steps
+func stepTable(tc models.DetailedTestCaseResponse) *models.TableWithData {
+	for i := range tc.Tables {
+		if len(tc.Tables[i].Steps) > 0 {
+			return &tc.Tables[i]
+		}
+	}
+	return nil
+}
+
+func stepsByNumber(steps []models.TestStep) map[int]models.TestStep {
+	byNumber := make(map[int]models.TestStep, len(steps))
+	for _, step := range steps {
+		byNumber[step.Number] = step
+	}
+	return byNumber
+}
+
+func rowCells(table models.TableWithData, blockID string) []string {
+	for _, row := range table.Rows {
+		if row.BlockID == blockID {
+			return row.Cells
+		}
+	}
+	return make([]string, table.TableWidth)
+}
+
+// mergeStepCells overwrites the cells of mapped columns that were present in
+// the spreadsheet and keeps all other cells of the existing row
+func mergeStepCells(existing []string, step models.TestStep, tableColumns []string, columns importColumns) []string {
+	spreadsheetColumn := map[string]string{
+		stepFieldAction:         ColumnAction,
+		stepFieldExpectedResult: ColumnExpectedResult,
+		stepFieldActualResult:   ColumnActualResult,
+		stepFieldStatus:         ColumnStepStatus,
+		stepFieldScreenshot:     ColumnScreenshot,
+	}
+
+	cells := make([]string, len(tableColumns))
+	copy(cells, existing)
+	values := StepCells(step, tableColumns)
+	for i, field := range tableColumns {
+		if column, ok := spreadsheetColumn[field]; ok && columns[column] {
+			cells[i] = values[i]
+		}
+	}
+	return cells
+}
+
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
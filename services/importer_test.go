@@ -0,0 +1,270 @@
+package services
+
+import (
+	"demo-notion-api/models"
+	"reflect"
+	"testing"
+)
+
+func TestGroupImportRows(t *testing.T) {
+	header := []string{ColumnProject, ColumnTestCaseKey, ColumnTitle, ColumnStatus, ColumnTags, ColumnStep, ColumnAction, ColumnExpectedResult}
+
+	tests := []struct {
+		name       string
+		rows       [][]string
+		wantCases  []importedTestCase
+		wantErrors []models.ImportError
+	}{
+		{
+			name: "rows are grouped by key in order of appearance",
+			rows: [][]string{
+				header,
+				{"web", "01002", "Logout", "Passed", "smoke, auth", "1", "Log out", "Logged out"},
+				{"web", "01001", "Login", "Failed", "", "1", "Log in", "Logged in"},
+				{"", "01002", "Logout", "Passed", "smoke,auth", "2", "'=cmd", "'-1"},
+			},
+			wantCases: []importedTestCase{
+				{
+					Key: "01002", Title: "Logout", Status: "Passed", Tags: []string{"smoke", "auth"}, HasSteps: true,
+					Steps: []models.TestStep{
+						{Number: 1, Action: "Log out", ExpectedResult: "Logged out"},
+						{Number: 2, Action: "=cmd", ExpectedResult: "-1"},
+					},
+				},
+				{
+					Key: "01001", Title: "Login", Status: "Failed", HasSteps: true,
+					Steps: []models.TestStep{{Number: 1, Action: "Log in", ExpectedResult: "Logged in"}},
+				},
+			},
+		},
+		{
+			name: "blank step cells continue from the previous step",
+			rows: [][]string{
+				header,
+				{"web", "01001", "Login", "Passed", "", "", "Open", ""},
+				{"web", "01002", "Logout", "Passed", "", "4", "Log in", ""},
+				{"web", "01001", "Login", "Passed", "", "", "", "Form shown"},
+				{"web", "01002", "Logout", "Passed", "", "", "Log out", "Logged out"},
+				{"web", "01001", "Login", "Passed", "", "5", "Submit", ""},
+				{"web", "01001", "Login", "Passed", "", "", "Wait", ""},
+			},
+			wantCases: []importedTestCase{
+				{
+					Key: "01001", Title: "Login", Status: "Passed", HasSteps: true,
+					Steps: []models.TestStep{
+						{Number: 1, Action: "Open"},
+						{Number: 2, ExpectedResult: "Form shown"},
+						{Number: 5, Action: "Submit"},
+						{Number: 6, Action: "Wait"},
+					},
+				},
+				{
+					Key: "01002", Title: "Logout", Status: "Passed", HasSteps: true,
+					Steps: []models.TestStep{
+						{Number: 4, Action: "Log in"},
+						{Number: 5, Action: "Log out", ExpectedResult: "Logged out"},
+					},
+				},
+			},
+		},
+		{
+			name: "a test case without step rows has no steps",
+			rows: [][]string{
+				header,
+				{"web", "01001", "Login", "Passed", "", "", "", ""},
+			},
+			wantCases: []importedTestCase{{Key: "01001", Title: "Login", Status: "Passed"}},
+		},
+		{
+			name: "rows of another project are rejected",
+			rows: [][]string{
+				header,
+				{"mobile", "01001", "Login", "Passed", "", "1", "Log in", "Logged in"},
+			},
+			wantErrors: []models.ImportError{{
+				Sheet: "s", Row: 2, TestCaseKey: "01001",
+				Message: `row belongs to project "mobile", not "web"; import it into that project`,
+			}},
+		},
+		{
+			name: "conflicting and invalid rows are reported",
+			rows: [][]string{
+				header,
+				{"web", "01001", "Login", "Passed", "", "1", "Log in", "Logged in"},
+				{"web", "01001", "Sign in", "Passed", "", "2", "Log out", "Logged out"},
+				{"web", "01001", "Login", "Passed", "", "x", "Log out", "Logged out"},
+				{"web", "01001", "Login", "Passed", "", "1", "Log in", "Changed"},
+				{"web", "", "Login", "", "", "", "", ""},
+				{"", "", "", "", "", "", "", ""},
+			},
+			wantCases: []importedTestCase{{
+				Key: "01001", Title: "Login", Status: "Passed", HasSteps: true,
+				Steps: []models.TestStep{{Number: 1, Action: "Log in", ExpectedResult: "Logged in"}},
+			}},
+			wantErrors: []models.ImportError{
+				{Sheet: "s", Row: 3, TestCaseKey: "01001", Message: `title "Sign in" conflicts with "Login"`},
+				{Sheet: "s", Row: 4, TestCaseKey: "01001", Message: `step number "x" is not a number`},
+				{Sheet: "s", Row: 5, TestCaseKey: "01001", Message: "step 1 is defined twice with different content"},
+				{Sheet: "s", Row: 6, Message: "row has no test case key"},
+			},
+		},
+		{
+			name:       "sheet without a key column",
+			rows:       [][]string{{ColumnTitle}, {"Login"}},
+			wantErrors: []models.ImportError{{Sheet: "s", Row: 1, Message: `missing "Test Case Key" column`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cases, _, errs := groupImportRows([]SpreadsheetSheet{{Name: "s", Rows: tt.rows}}, "web")
+			if !reflect.DeepEqual(cases, tt.wantCases) {
+				t.Errorf("test cases = %+v, want %+v", cases, tt.wantCases)
+			}
+			if !reflect.DeepEqual(errs, tt.wantErrors) {
+				t.Errorf("errors = %+v, want %+v", errs, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestDiffTestCase(t *testing.T) {
+	current := models.DetailedTestCaseResponse{
+		PageID: "page",
+		Title:  "Login",
+		Status: "Passed",
+		Tables: []models.TableWithData{
+			{BlockID: "notes"},
+			{BlockID: "steps", Steps: []models.TestStep{
+				{Number: 1, Action: "Open", ExpectedResult: "Opened"},
+				{Number: 2, Action: "Log in", ExpectedResult: "Logged in"},
+			}},
+		},
+	}
+	allColumns := importColumns{ColumnTitle: true, ColumnStatus: true, ColumnStep: true, ColumnAction: true, ColumnExpectedResult: true}
+	status := "Failed"
+
+	tests := []struct {
+		name          string
+		tc            importedTestCase
+		columns       importColumns
+		deleteMissing bool
+		wantFields    []models.FieldChange
+		wantSteps     []models.StepChange
+		wantUpdate    models.TestCaseUpdate
+	}{
+		{
+			name: "unchanged",
+			tc: importedTestCase{Title: "Login", Status: "Passed", HasSteps: true, Steps: []models.TestStep{
+				{Number: 1, Action: "Open", ExpectedResult: "Opened"},
+				{Number: 2, Action: "Log in", ExpectedResult: "Logged in"},
+			}},
+			columns: allColumns,
+		},
+		{
+			name: "changed fields and steps",
+			tc: importedTestCase{Title: "Login", Status: "Failed", HasSteps: true, Steps: []models.TestStep{
+				{Number: 1, Action: "Open app", ExpectedResult: "Opened"},
+				{Number: 3, Action: "Log out", ExpectedResult: "Logged out"},
+			}},
+			columns:    allColumns,
+			wantFields: []models.FieldChange{{Field: ColumnStatus, Old: "Passed", New: "Failed"}},
+			wantSteps: []models.StepChange{
+				{Number: 1, Change: StepModified, Fields: []models.FieldChange{{Field: ColumnAction, Old: "Open", New: "Open app"}}},
+				{Number: 3, Change: StepAdded},
+			},
+			wantUpdate: models.TestCaseUpdate{Status: &status},
+		},
+		{
+			name: "missing steps are removed only with deleteMissing",
+			tc: importedTestCase{Title: "Login", Status: "Passed", HasSteps: true, Steps: []models.TestStep{
+				{Number: 1, Action: "Open", ExpectedResult: "Opened"},
+			}},
+			columns:       allColumns,
+			deleteMissing: true,
+			wantSteps:     []models.StepChange{{Number: 2, Change: StepRemoved}},
+		},
+		{
+			name:          "a test case without step rows keeps its steps",
+			tc:            importedTestCase{Title: "Login", Status: "Passed"},
+			columns:       allColumns,
+			deleteMissing: true,
+		},
+		{
+			name: "missing columns are not compared",
+			tc: importedTestCase{Title: "Renamed", Status: "Failed", HasSteps: true, Steps: []models.TestStep{
+				{Number: 1, Action: "Open", ExpectedResult: "Changed"},
+				{Number: 2, Action: "Log in", ExpectedResult: "Logged in"},
+			}},
+			columns:    importColumns{ColumnTitle: true, ColumnStep: true, ColumnAction: true},
+			wantFields: []models.FieldChange{{Field: ColumnTitle, Old: "Login", New: "Renamed"}},
+			wantUpdate: models.TestCaseUpdate{Title: stringPtr("Renamed")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change, update := diffTestCase(tt.tc, current, tt.columns, tt.deleteMissing)
+			if change.PageID != "page" {
+				t.Errorf("page ID = %q, want %q", change.PageID, "page")
+			}
+			if !reflect.DeepEqual(change.Fields, tt.wantFields) {
+				t.Errorf("fields = %+v, want %+v", change.Fields, tt.wantFields)
+			}
+			if !reflect.DeepEqual(change.Steps, tt.wantSteps) {
+				t.Errorf("steps = %+v, want %+v", change.Steps, tt.wantSteps)
+			}
+			if !reflect.DeepEqual(update, tt.wantUpdate) {
+				t.Errorf("update = %+v, want %+v", update, tt.wantUpdate)
+			}
+		})
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	tc := models.DetailedTestCaseResponse{
+		Project:     "web",
+		TestCaseKey: "01001",
+		PageID:      "page",
+		Title:       "TC_01001 Login",
+		Status:      "Passed",
+		Tags:        []string{"smoke", "auth"},
+		Tables: []models.TableWithData{
+			{BlockID: "env", Steps: nil},
+			{BlockID: "steps", Steps: []models.TestStep{
+				{Number: 1, Action: "=HYPERLINK(\"x\")", ExpectedResult: "+1 shown"},
+				{Number: 2, Action: "'quoted", ExpectedResult: "@user greeted"},
+			}},
+			{BlockID: "other", Steps: []models.TestStep{{Number: 9, Action: "Not exported"}}},
+		},
+	}
+
+	rows := append([][]string{SpreadsheetColumns}, escapeRows(FlattenTestCase(tc))...)
+	for _, row := range rows[1:] {
+		for _, cell := range row {
+			if cell != "" && isFormulaStart(cell[0]) {
+				t.Errorf("exported cell %q starts a formula", cell)
+			}
+		}
+	}
+
+	imported, columns, errs := groupImportRows([]SpreadsheetSheet{{Name: "s", Rows: rows}}, "web")
+	if len(errs) > 0 {
+		t.Fatalf("errors = %+v", errs)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("imported %d test cases, want 1", len(imported))
+	}
+
+	change, update := diffTestCase(imported[0], tc, columns, true)
+	if len(change.Fields) > 0 || len(change.Steps) > 0 {
+		t.Errorf("change = %+v, want none", change)
+	}
+	if !reflect.DeepEqual(update, models.TestCaseUpdate{}) {
+		t.Errorf("update = %+v, want none", update)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type JUnitService struct {
+	suiteService *SuiteService
+}
+
+func NewJUnitService(suiteService *SuiteService) *JUnitService {
+	return &JUnitService{
+		suiteService: suiteService,
+	}
+}
+
+// WithContext returns a copy of the service whose Notion calls carry ctx
+func (s *JUnitService) WithContext(ctx context.Context) *JUnitService {
+	return &JUnitService{suiteService: s.suiteService.WithContext(ctx)}
+}
+
+// TestCasesReport builds a JUnit report with every test case in a single suite.
+// The test cases are returned too, so that callers can check them with
+// CheckComplete; those that could not be read are reported as error elements.
+func (s *JUnitService) TestCasesReport() (report *models.JUnitTestSuites, testCases []models.DetailedTestCaseResponse, err error) {
+	notionService := s.suiteService.NotionService()
+	detailed, err := notionService.GetDetailedTestCases()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	suite := buildJUnitSuite("notion-test-cases", detailed, notionService.Schema())
+	return buildJUnitReport("notion-test-cases", []models.JUnitTestSuite{suite}), detailed, nil
+}
+
+// PlanReport builds a JUnit report for a plan with one testsuite per suite of
+// the plan. The test cases of the plan are returned as in TestCasesReport.
+func (s *JUnitService) PlanReport(name string) (report *models.JUnitTestSuites, testCases []models.DetailedTestCaseResponse, err error) {
+	plan, err := s.suiteService.findPlan(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notionService := s.suiteService.NotionService()
+	detailed, err := notionService.GetDetailedTestCases()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var suites []models.JUnitTestSuite
+	var planned []models.DetailedTestCaseResponse
+	for _, suiteName := range plan.Suites {
+		suite, err := s.suiteService.findSuite(suiteName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var members []models.DetailedTestCaseResponse
+		for _, tc := range detailed {
+			if suiteMatches(*suite, summaryOf(tc)) {
+				members = append(members, tc)
+			}
+		}
+		planned = append(planned, members...)
+		suites = append(suites, buildJUnitSuite(suite.Name, members, notionService.Schema()))
+	}
+
+	return buildJUnitReport(plan.Name, suites), planned, nil
+}
+
+// Helper functions
+
+func buildJUnitReport(name string, suites []models.JUnitTestSuite) *models.JUnitTestSuites {
+	report := &models.JUnitTestSuites{Name: name, Suites: suites}
+	for _, suite := range suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+	}
+	return report
+}
+
+// buildJUnitSuite maps each test case to a testcase element by its status outcome.
+// Test cases whose tables could not be read are reported as errors, since their
+// steps are unknown; blocked and not yet executed test cases are reported as skipped.
+// Timestamps come from the test dates: each testcase carries its own and the
+// suite carries the latest, so that a report built twice is identical.
+func buildJUnitSuite(name string, testCases []models.DetailedTestCaseResponse, schema *config.Schema) models.JUnitTestSuite {
+	suite := models.JUnitTestSuite{
+		Name:      name,
+		TestCases: []models.JUnitTestCase{},
+	}
+	var latest time.Time
+
+	for _, tc := range testCases {
+		className := tc.Project
+		if className == "" {
+			className = name
+		}
+
+		testCase := models.JUnitTestCase{
+			Name:      tc.Title,
+			ClassName: className,
+			SystemOut: tc.URL,
+		}
+		if date, ok := parseTestDate(tc.TestDate); ok {
+			testCase.Timestamp = date.Format(junitTimestampLayout)
+			if date.After(latest) {
+				latest = date
+			}
+		}
+
+		if len(tc.Errors) > 0 {
+			testCase.Error = readErrorDetails(tc)
+			suite.Errors++
+			suite.TestCases = append(suite.TestCases, testCase)
+			suite.Tests++
+			continue
+		}
+
+		switch outcome := schema.ClassifyStatus(tc.Status); outcome {
+		case models.OutcomePassed:
+		case models.OutcomeFailed:
+			message, details := failureDetails(tc, schema)
+			testCase.Failure = &models.JUnitMessage{
+				Message: message,
+				Type:    tc.Status,
+				Content: details,
+			}
+			suite.Failures++
+		default:
+			testCase.Skipped = &models.JUnitMessage{Message: skippedMessage(tc.Status, outcome)}
+			suite.Skipped++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+	}
+
+	if !latest.IsZero() {
+		suite.Timestamp = latest.Format(junitTimestampLayout)
+	}
+	return suite
+}
+
+// junitTimestampLayout is the ISO 8601 layout without zone that JUnit uses
+const junitTimestampLayout = "2006-01-02T15:04:05"
+
+// parseTestDate parses a Notion date, which is a day or a time with a zone
+func parseTestDate(date string) (time.Time, bool) {
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		return t.UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// failureDetails describes the failing steps of a test case; steps are failing
+// when their own status classifies as failed
+func failureDetails(tc models.DetailedTestCaseResponse, schema *config.Schema) (string, string) {
+	var failed []models.TestStep
+	for _, table := range tc.Tables {
+		for _, step := range table.Steps {
+			if schema.ClassifyStatus(step.Status) == models.OutcomeFailed {
+				failed = append(failed, step)
+			}
+		}
+	}
+
+	if len(failed) == 0 {
+		return fmt.Sprintf("Status: %s", tc.Status), ""
+	}
+
+	var lines []string
+	for _, step := range failed {
+		lines = append(lines, fmt.Sprintf("Step %d: %s\n  Expected: %s\n  Actual: %s",
+			step.Number, step.Action, step.ExpectedResult, step.ActualResult))
+	}
+
+	first := failed[0]
+	message := fmt.Sprintf("Step %d failed: expected %q, actual %q", first.Number, first.ExpectedResult, first.ActualResult)
+	if len(failed) > 1 {
+		message = fmt.Sprintf("%s (and %d more failing steps)", message, len(failed)-1)
+	}
+	return message, strings.Join(lines, "\n")
+}
+
+// readErrorDetails describes why a test case could not be read from Notion
+func readErrorDetails(tc models.DetailedTestCaseResponse) *models.JUnitMessage {
+	var lines []string
+	for _, readErr := range tc.Errors {
+		lines = append(lines, readErr.Message)
+	}
+	return &models.JUnitMessage{
+		Message: fmt.Sprintf("Could not read the test case from Notion (status: %s): %s", tc.Status, tc.Errors[0].Message),
+		Type:    "NotionReadError",
+		Content: strings.Join(lines, "\n"),
+	}
+}
+
+func skippedMessage(status, outcome string) string {
+	if status == "" {
+		return "Not run"
+	}
+	if outcome == models.OutcomeSkipped {
+		return status
+	}
+	return fmt.Sprintf("Not executed (status: %s)", status)
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"testing"
+)
+
+func TestBuildJUnitSuite(t *testing.T) {
+	testCases := []models.DetailedTestCaseResponse{
+		{Title: "TC_01001 Login", Status: "Passed", TestDate: "2026-01-02"},
+		{
+			Title: "TC_01002 Logout", Status: "Failed", TestDate: "2026-01-05T10:00:00.000+07:00",
+			Tables: []models.TableWithData{{Steps: []models.TestStep{
+				{Number: 1, Action: "Open", ExpectedResult: "Opened", ActualResult: "Opened", Status: "Passed"},
+				{Number: 2, Action: "Log out", ExpectedResult: "Logged out", ActualResult: "Error", Status: "Failed"},
+			}}},
+		},
+		{Title: "TC_01003 Profile", Status: "Blocked"},
+		{Title: "TC_01004 Settings", Status: "", TestDate: "not a date"},
+		{
+			Title: "TC_01005 Search", Status: "Passed", TestDate: "2026-01-03",
+			Errors: []models.TestCaseError{{Message: "status 502"}, {Message: "status 504"}},
+		},
+	}
+
+	suite := buildJUnitSuite("suite", testCases, config.DefaultSchema())
+
+	if suite.Tests != 5 || suite.Failures != 1 || suite.Errors != 1 || suite.Skipped != 2 {
+		t.Errorf("counts = tests %d, failures %d, errors %d, skipped %d, want 5, 1, 1, 2",
+			suite.Tests, suite.Failures, suite.Errors, suite.Skipped)
+	}
+	if suite.Timestamp != "2026-01-05T03:00:00" {
+		t.Errorf("suite timestamp = %q, want the latest test date", suite.Timestamp)
+	}
+
+	tests := []struct {
+		name          string
+		wantTimestamp string
+		wantFailure   string
+		wantError     string
+		wantSkipped   string
+	}{
+		{name: "TC_01001 Login", wantTimestamp: "2026-01-02T00:00:00"},
+		{name: "TC_01002 Logout", wantTimestamp: "2026-01-05T03:00:00", wantFailure: `Step 2 failed: expected "Logged out", actual "Error"`},
+		{name: "TC_01003 Profile", wantSkipped: "Not executed (status: Blocked)"},
+		{name: "TC_01004 Settings", wantSkipped: "Not run"},
+		{name: "TC_01005 Search", wantTimestamp: "2026-01-03T00:00:00", wantError: "Could not read the test case from Notion (status: Passed): status 502"},
+	}
+
+	message := func(m *models.JUnitMessage) string {
+		if m == nil {
+			return ""
+		}
+		return m.Message
+	}
+	for i, tt := range tests {
+		tc := suite.TestCases[i]
+		if tc.Name != tt.name {
+			t.Fatalf("testcase %d = %q, want %q", i, tc.Name, tt.name)
+		}
+		if tc.Timestamp != tt.wantTimestamp {
+			t.Errorf("%s: timestamp = %q, want %q", tt.name, tc.Timestamp, tt.wantTimestamp)
+		}
+		if got := message(tc.Failure); got != tt.wantFailure {
+			t.Errorf("%s: failure = %q, want %q", tt.name, got, tt.wantFailure)
+		}
+		if got := message(tc.Error); got != tt.wantError {
+			t.Errorf("%s: error = %q, want %q", tt.name, got, tt.wantError)
+		}
+		if got := message(tc.Skipped); got != tt.wantSkipped {
+			t.Errorf("%s: skipped = %q, want %q", tt.name, got, tt.wantSkipped)
+		}
+		if tc.Time != 0 {
+			t.Errorf("%s: time = %v, want none", tt.name, tc.Time)
+		}
+	}
+
+	if content := suite.TestCases[4].Error.Content; content != "status 502\nstatus 504" {
+		t.Errorf("error content = %q, want every read error", content)
+	}
+}
+
+func TestJUnitTimestampRoundTrip(t *testing.T) {
+	suite := buildJUnitSuite("suite", []models.DetailedTestCaseResponse{{Title: "TC_01001 Login", TestDate: "2026-01-02"}}, config.DefaultSchema())
+	if got := suiteDate(suite.Timestamp); got != "2026-01-02" {
+		t.Errorf("ingested date = %q, want the exported test date", got)
+	}
+}
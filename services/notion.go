@@ -2,47 +2,160 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"demo-notion-api/config"
+	"demo-notion-api/logging"
+	"demo-notion-api/metrics"
 	"demo-notion-api/models"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
-	"regexp"
 	"strings"
+	"sync"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var ErrTestCaseNotFound = errors.New("test case not found")
+
+// Notion responses that may succeed when retried later
+var (
+	ErrNotionRateLimited = errors.New("notion API rate limited")
+	ErrNotionUnavailable = errors.New("notion API unavailable")
 )
 
 type NotionService struct {
-	config *config.Config
-	client *http.Client
+	config      *config.Config
+	schema      *config.Schema
+	stepColumns map[string]string
+	client      *http.Client
+	users       *userCache
+
+	// ctx is the context of the request the service works for; see WithContext
+	ctx context.Context
+}
+
+// userCache holds user names shared by every copy of a service
+type userCache struct {
+	mu    sync.Mutex
+	names map[string]string
 }
 
 func NewNotionService(cfg *config.Config) *NotionService {
+	schema := cfg.Schema
+	if schema == nil {
+		schema = config.DefaultSchema()
+	}
+
 	return &NotionService{
-		config: cfg,
-		client: &http.Client{},
+		config:      cfg,
+		schema:      schema,
+		stepColumns: StepColumnLookup(schema),
+		client:      &http.Client{Transport: otelhttp.NewTransport(&logging.Transport{Upstream: "notion", Base: &metrics.Transport{Upstream: "notion"}})},
+		users:       &userCache{names: make(map[string]string)},
+		ctx:         context.Background(),
 	}
 }
 
-// SearchTestCases searches for pages with "External tasks" query and extracts test cases
+// WithContext returns a copy of the service whose Notion calls carry ctx, so
+// that they are cancelled with the request and logged with its request ID
+func (s *NotionService) WithContext(ctx context.Context) *NotionService {
+	scoped := *s
+	scoped.ctx = ctx
+	return &scoped
+}
+
+// tracer starts the spans of NotionService calls
+var tracer = otel.Tracer("demo-notion-api/services")
+
+// startSpan starts a span for a call as a child of the span in the service
+// context and returns a copy of the service whose Notion calls nest under it
+func (s *NotionService) startSpan(name string, attrs ...attribute.KeyValue) (*NotionService, trace.Span) {
+	ctx, span := tracer.Start(s.ctx, "NotionService."+name, trace.WithAttributes(attrs...))
+	return s.WithContext(ctx), span
+}
+
+// Schema returns the schema used to read test case pages
+func (s *NotionService) Schema() *config.Schema {
+	return s.schema
+}
+
+// maxConcurrentReads bounds the test cases whose table data is read in
+// parallel, staying near the Notion rate limit of three requests per second
+const maxConcurrentReads = 4
+
+// notionPageSize is the largest page size the Notion API accepts
+const notionPageSize = 100
+
+// SearchTestCases searches for pages with "External tasks" query and extracts test cases.
+// When a database ID is configured, the database is queried instead of the whole workspace.
+// Results are paged by Notion, so pages are fetched until has_more is false.
 func (s *NotionService) SearchTestCases() ([]models.TestCaseResponse, error) {
-	searchReq := models.NotionSearchRequest{
-		Filter: models.NotionSearchFilter{
-			Value:    "page",
-			Property: "object",
-		},
-		Sort: models.NotionSearchSort{
-			Direction: "ascending",
-			Timestamp: "last_edited_time",
-		},
+	s, span := s.startSpan("SearchTestCases")
+	defer span.End()
+
+	var pages []models.NotionPage
+	cursor := ""
+	for {
+		searchResp, err := s.searchPage(cursor)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, searchResp.Results...)
+
+		if !searchResp.HasMore || searchResp.NextCursor == "" {
+			break
+		}
+		cursor = searchResp.NextCursor
+	}
+	span.SetAttributes(attribute.Int("notion.pages", len(pages)))
+
+	return s.extractTestCases(pages), nil
+}
+
+// searchPage fetches one page of search or database query results starting at cursor
+func (s *NotionService) searchPage(cursor string) (*models.NotionSearchResponse, error) {
+	var url string
+	var payload interface{}
+	if s.config.NotionDatabaseID != "" {
+		url = fmt.Sprintf("%s/databases/%s/query", s.config.NotionAPIURL, s.config.NotionDatabaseID)
+		payload = models.NotionDatabaseQueryRequest{
+			Sorts: []models.NotionSearchSort{{
+				Direction: "ascending",
+				Timestamp: "last_edited_time",
+			}},
+			StartCursor: cursor,
+			PageSize:    notionPageSize,
+		}
+	} else {
+		url = s.config.NotionAPIURL + "/search"
+		payload = models.NotionSearchRequest{
+			Filter: models.NotionSearchFilter{
+				Value:    "page",
+				Property: "object",
+			},
+			Sort: models.NotionSearchSort{
+				Direction: "ascending",
+				Timestamp: "last_edited_time",
+			},
+			StartCursor: cursor,
+			PageSize:    notionPageSize,
+		}
 	}
 
-	reqBody, err := json.Marshal(searchReq)
+	reqBody, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal search request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", s.config.NotionAPIURL+"/search", bytes.NewBuffer(reqBody))
+	req, err := http.NewRequestWithContext(s.ctx, "POST", url, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -56,8 +169,7 @@ func (s *NotionService) SearchTestCases() ([]models.TestCaseResponse, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("notion API error: status %d, body: %s", resp.StatusCode, string(body))
+		return nil, notionAPIError(resp)
 	}
 
 	var searchResp models.NotionSearchResponse
@@ -65,12 +177,14 @@ func (s *NotionService) SearchTestCases() ([]models.TestCaseResponse, error) {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	fmt.Println("searchResp.Results", searchResp.Results)
-	return s.extractTestCases(searchResp.Results), nil
+	return &searchResp, nil
 }
 
 // GetTestCaseByKey finds a test case by its key (e.g., "01001" from "TC_01001")
 func (s *NotionService) GetTestCaseByKey(testCaseKey string) (*models.TestCaseResponse, error) {
+	s, span := s.startSpan("GetTestCaseByKey", attribute.String("test_case.key", testCaseKey))
+	defer span.End()
+
 	testCases, err := s.SearchTestCases()
 	if err != nil {
 		return nil, err
@@ -82,14 +196,17 @@ func (s *NotionService) GetTestCaseByKey(testCaseKey string) (*models.TestCaseRe
 		}
 	}
 
-	return nil, fmt.Errorf("test case with key %s not found", testCaseKey)
+	return nil, fmt.Errorf("%w: %s", ErrTestCaseNotFound, testCaseKey)
 }
 
 // GetPageBlocks retrieves all blocks from a page
 func (s *NotionService) GetPageBlocks(pageID string) ([]models.BlockResponse, error) {
+	s, span := s.startSpan("GetPageBlocks", attribute.String("notion.page_id", pageID))
+	defer span.End()
+
 	url := fmt.Sprintf("%s/blocks/%s/children", s.config.NotionAPIURL, pageID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(s.ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -103,8 +220,7 @@ func (s *NotionService) GetPageBlocks(pageID string) ([]models.BlockResponse, er
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("notion API error: status %d, body: %s", resp.StatusCode, string(body))
+		return nil, notionAPIError(resp)
 	}
 
 	var blocksResp models.NotionBlocksResponse
@@ -117,9 +233,12 @@ func (s *NotionService) GetPageBlocks(pageID string) ([]models.BlockResponse, er
 
 // GetBlockDetails retrieves detailed information about a specific block
 func (s *NotionService) GetBlockDetails(blockID string) (*models.BlockResponse, error) {
+	s, span := s.startSpan("GetBlockDetails", attribute.String("notion.block_id", blockID))
+	defer span.End()
+
 	url := fmt.Sprintf("%s/blocks/%s", s.config.NotionAPIURL, blockID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(s.ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -133,8 +252,7 @@ func (s *NotionService) GetBlockDetails(blockID string) (*models.BlockResponse,
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("notion API error: status %d, body: %s", resp.StatusCode, string(body))
+		return nil, notionAPIError(resp)
 	}
 
 	var block models.NotionBlock
@@ -145,70 +263,249 @@ func (s *NotionService) GetBlockDetails(blockID string) (*models.BlockResponse,
 	return s.convertToBlockResponse(block), nil
 }
 
+// GetBlockTree retrieves the blocks of a page with their nested children.
+// Table rows are left out; they are read through GetTableData.
+func (s *NotionService) GetBlockTree(pageID string) ([]models.BlockResponse, error) {
+	s, span := s.startSpan("GetBlockTree", attribute.String("notion.page_id", pageID))
+	defer span.End()
+
+	blocks, err := s.GetPageBlocks(pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range blocks {
+		if !blocks[i].HasChildren || blocks[i].Type == "table" {
+			continue
+		}
+		children, err := s.GetBlockTree(blocks[i].BlockID)
+		if err != nil {
+			return nil, err
+		}
+		blocks[i].Children = children
+	}
+
+	return blocks, nil
+}
+
+// GetUserName returns the name of a Notion user, or the ID when the integration
+// cannot read user information. Names are cached for the life of the service.
+func (s *NotionService) GetUserName(userID string) string {
+	if userID == "" {
+		return ""
+	}
+
+	s.users.mu.Lock()
+	name, ok := s.users.names[userID]
+	s.users.mu.Unlock()
+	metrics.CacheLookup("users", ok)
+	if ok {
+		return name
+	}
+
+	s, span := s.startSpan("GetUserName", attribute.String("notion.user_id", userID))
+	defer span.End()
+
+	var user models.NotionUser
+	url := fmt.Sprintf("%s/users/%s", s.config.NotionAPIURL, userID)
+	if err := s.doRequest("GET", url, nil, &user); err != nil || user.Name == "" {
+		return userID
+	}
+
+	s.users.mu.Lock()
+	s.users.names[userID] = user.Name
+	s.users.mu.Unlock()
+	return user.Name
+}
+
+// HasAPIKey reports whether a Notion API key is configured
+func (s *NotionService) HasAPIKey() bool {
+	return s.config.NotionAPIKey != ""
+}
+
+// GetCurrentUser returns the bot user of the API key; a cheap call to check
+// that the key is accepted
+func (s *NotionService) GetCurrentUser() (*models.NotionUser, error) {
+	s, span := s.startSpan("GetCurrentUser")
+	defer span.End()
+
+	var user models.NotionUser
+	url := fmt.Sprintf("%s/users/me", s.config.NotionAPIURL)
+	if err := s.doRequest("GET", url, nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// CheckDatabase checks that the configured database can be read with the API key
+func (s *NotionService) CheckDatabase() error {
+	s, span := s.startSpan("CheckDatabase", attribute.String("notion.database_id", s.config.NotionDatabaseID))
+	defer span.End()
+
+	url := fmt.Sprintf("%s/databases/%s", s.config.NotionAPIURL, s.config.NotionDatabaseID)
+	return s.doRequest("GET", url, nil, nil)
+}
+
 // GetTableBlocks filters blocks to return only table type blocks
 func (s *NotionService) GetTableBlocks(pageID string) ([]models.BlockResponse, error) {
+	s, span := s.startSpan("GetTableBlocks", attribute.String("notion.page_id", pageID))
+	defer span.End()
+
 	blocks, err := s.GetPageBlocks(pageID)
 	if err != nil {
 		return nil, err
 	}
 
+	return tableBlocksOf(blocks), nil
+}
+
+// tableBlocksOf returns the tables among the top-level blocks of a page
+func tableBlocksOf(blocks []models.BlockResponse) []models.BlockResponse {
 	var tableBlocks []models.BlockResponse
 	for _, block := range blocks {
 		if block.Type == "table" {
 			tableBlocks = append(tableBlocks, block)
 		}
 	}
-
-	return tableBlocks, nil
+	return tableBlocks
 }
 
 // GetDetailedTestCases searches for test cases and includes their table data
 func (s *NotionService) GetDetailedTestCases() ([]models.DetailedTestCaseResponse, error) {
+	s, span := s.startSpan("GetDetailedTestCases")
+	defer span.End()
+
 	// First get all test cases
 	testCases, err := s.SearchTestCases()
 	if err != nil {
 		return nil, fmt.Errorf("failed to search test cases: %w", err)
 	}
 
-	var detailedTestCases []models.DetailedTestCaseResponse
+	detailedTestCases := make([]models.DetailedTestCaseResponse, len(testCases))
+	s.readDetailedTestCases(testCases, func(i int, detailed models.DetailedTestCaseResponse) error {
+		detailedTestCases[i] = detailed
+		return nil
+	})
 
-	for _, tc := range testCases {
-		detailed := models.DetailedTestCaseResponse{
+	return detailedTestCases, nil
+}
+
+// readDetailedTestCases reads the table data of up to maxConcurrentReads test
+// cases at a time and passes them to emit in the order of testCases. It stops
+// at the first error returned by emit and returns it.
+func (s *NotionService) readDetailedTestCases(testCases []models.TestCaseResponse, emit func(int, models.DetailedTestCaseResponse) error) error {
+	results := make([]chan models.DetailedTestCaseResponse, len(testCases))
+	for i := range results {
+		results[i] = make(chan models.DetailedTestCaseResponse, 1)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		slots := make(chan struct{}, maxConcurrentReads)
+		for i, tc := range testCases {
+			select {
+			case slots <- struct{}{}:
+			case <-stop:
+				return
+			}
+			go func() {
+				defer func() { <-slots }()
+				results[i] <- s.GetDetailedTestCase(tc)
+			}()
+		}
+	}()
+
+	for i := range testCases {
+		if err := emit(i, <-results[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDetailedTestCase adds the table data of a test case page. Tables that
+// cannot be read are left out and reported in the Errors of the test case.
+func (s *NotionService) GetDetailedTestCase(tc models.TestCaseResponse) models.DetailedTestCaseResponse {
+	s, span := s.startSpan("GetDetailedTestCase", attribute.String("test_case.key", tc.TestCaseKey), attribute.String("notion.page_id", tc.PageID))
+	defer span.End()
+
+	// Get table blocks for this test case
+	tableBlocks, err := s.GetTableBlocks(tc.PageID)
+	if err != nil {
+		detailed := detailedOf(tc)
+		detailed.Tables = []models.TableWithData{}
+		detailed.Errors = []models.TestCaseError{{
 			TestCaseKey: tc.TestCaseKey,
 			PageID:      tc.PageID,
-			Title:       tc.Title,
-			Status:      tc.Status,
-			TestDate:    tc.TestDate,
-			URL:         tc.URL,
-			LastEdited:  tc.LastEdited,
-		}
+			Message:     fmt.Sprintf("failed to get table blocks: %v", err),
+		}}
+		span.SetStatus(codes.Error, detailed.Errors[0].Message)
+		return detailed
+	}
+
+	return s.readTables(span, tc, tableBlocks)
+}
 
-		// Get table blocks for this test case
-		tableBlocks, err := s.GetTableBlocks(tc.PageID)
+// GetDetailedTestCaseFromBlocks is GetDetailedTestCase for a page whose block
+// tree was already read with GetBlockTree, so the page is not read again
+func (s *NotionService) GetDetailedTestCaseFromBlocks(tc models.TestCaseResponse, blocks []models.BlockResponse) models.DetailedTestCaseResponse {
+	s, span := s.startSpan("GetDetailedTestCaseFromBlocks", attribute.String("test_case.key", tc.TestCaseKey), attribute.String("notion.page_id", tc.PageID))
+	defer span.End()
+
+	return s.readTables(span, tc, tableBlocksOf(blocks))
+}
+
+// readTables adds the data of tableBlocks to tc. Tables that cannot be read
+// are left out and reported in the Errors of the test case.
+func (s *NotionService) readTables(span trace.Span, tc models.TestCaseResponse, tableBlocks []models.BlockResponse) models.DetailedTestCaseResponse {
+	detailed := detailedOf(tc)
+
+	// Get table data for each table block
+	for _, tableBlock := range tableBlocks {
+		tableData, err := s.GetTableData(tableBlock.BlockID)
 		if err != nil {
-			// Log error but continue with other test cases
-			fmt.Printf("Warning: failed to get table blocks for test case %s: %v\n", tc.TestCaseKey, err)
-			detailed.Tables = []models.TableWithData{}
-		} else {
-			// Get table data for each table block
-			for _, tableBlock := range tableBlocks {
-				tableData, err := s.GetTableData(tableBlock.BlockID)
-				if err != nil {
-					fmt.Printf("Warning: failed to get table data for block %s: %v\n", tableBlock.BlockID, err)
-					continue
-				}
-				detailed.Tables = append(detailed.Tables, *tableData)
-			}
+			detailed.Errors = append(detailed.Errors, models.TestCaseError{
+				TestCaseKey: tc.TestCaseKey,
+				PageID:      tc.PageID,
+				BlockID:     tableBlock.BlockID,
+				Message:     fmt.Sprintf("failed to get table data for block %s: %v", tableBlock.BlockID, err),
+			})
+			continue
 		}
+		detailed.Tables = append(detailed.Tables, *tableData)
+	}
 
-		detailedTestCases = append(detailedTestCases, detailed)
+	span.SetAttributes(attribute.Int("test_case.tables", len(detailed.Tables)), attribute.Int("test_case.errors", len(detailed.Errors)))
+	if len(detailed.Errors) > 0 {
+		span.SetStatus(codes.Error, detailed.Errors[0].Message)
 	}
 
-	return detailedTestCases, nil
+	return detailed
+}
+
+// detailedOf copies the properties of tc into a detailed test case without tables
+func detailedOf(tc models.TestCaseResponse) models.DetailedTestCaseResponse {
+	return models.DetailedTestCaseResponse{
+		Project:      tc.Project,
+		TestCaseKey:  tc.TestCaseKey,
+		PageID:       tc.PageID,
+		Title:        tc.Title,
+		Status:       tc.Status,
+		TestDate:     tc.TestDate,
+		Tags:         tc.Tags,
+		Assignees:    tc.Assignees,
+		URL:          tc.URL,
+		LastEdited:   tc.LastEdited,
+		LastEditedBy: tc.LastEditedBy,
+	}
 }
 
 // GetTableData retrieves table data including all rows
 func (s *NotionService) GetTableData(tableBlockID string) (*models.TableWithData, error) {
+	s, span := s.startSpan("GetTableData", attribute.String("notion.block_id", tableBlockID))
+	defer span.End()
+
 	// First get the table block info
 	tableBlock, err := s.GetBlockDetails(tableBlockID)
 	if err != nil {
@@ -222,7 +519,7 @@ func (s *NotionService) GetTableData(tableBlockID string) (*models.TableWithData
 	// Get table rows (children of the table block)
 	url := fmt.Sprintf("%s/blocks/%s/children", s.config.NotionAPIURL, tableBlockID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(s.ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -236,8 +533,7 @@ func (s *NotionService) GetTableData(tableBlockID string) (*models.TableWithData
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("notion API error: status %d, body: %s", resp.StatusCode, string(body))
+		return nil, notionAPIError(resp)
 	}
 
 	var blocksResp models.NotionBlocksResponse
@@ -254,7 +550,7 @@ func (s *NotionService) GetTableData(tableBlockID string) (*models.TableWithData
 				cellContent := s.extractRichTextContent(cellArray)
 				cells = append(cells, cellContent)
 			}
-			rows = append(rows, models.TableRow{Cells: cells})
+			rows = append(rows, models.TableRow{BlockID: block.ID, Cells: cells})
 		}
 	}
 
@@ -266,11 +562,214 @@ func (s *NotionService) GetTableData(tableBlockID string) (*models.TableWithData
 		Rows:            rows,
 	}
 
+	// Map step table rows to typed steps alongside the raw rows
+	tableData.Steps, tableData.Warnings = ParseSteps(*tableData, s.stepColumns)
+
 	return tableData, nil
 }
 
+// UpdateTestCase writes the set fields of update to the page's schema properties
+func (s *NotionService) UpdateTestCase(pageID string, update models.TestCaseUpdate) error {
+	s, span := s.startSpan("UpdateTestCase", attribute.String("notion.page_id", pageID))
+	defer span.End()
+
+	properties := s.testCaseProperties(update)
+	if len(properties) == 0 {
+		return nil
+	}
+	return s.UpdatePageProperties(pageID, properties)
+}
+
+// CreateTestCase creates a test case page with a step table built from steps
+func (s *NotionService) CreateTestCase(update models.TestCaseUpdate, steps []models.TestStep) (*models.NotionPage, error) {
+	s, span := s.startSpan("CreateTestCase")
+	defer span.End()
+
+	var children []interface{}
+	if len(steps) > 0 {
+		header := s.StepTableHeader()
+		var rows [][]string
+		for _, step := range steps {
+			rows = append(rows, StepCells(step, DefaultStepOrder()))
+		}
+		children = append(children, TablePayload(header, rows))
+	}
+
+	return s.CreatePage(s.testCaseProperties(update), children)
+}
+
+// StepTableHeader returns the header row used for new step tables
+func (s *NotionService) StepTableHeader() []string {
+	var header []string
+	for _, field := range defaultStepOrder {
+		names := defaultStepColumns[field]
+		if configured, ok := s.schema.StepColumns[field]; ok {
+			names = configured
+		}
+		header = append(header, names[0])
+	}
+	return header
+}
+
+// CreatePage creates a page in the configured database with the given properties and child blocks
+func (s *NotionService) CreatePage(properties map[string]interface{}, children []interface{}) (*models.NotionPage, error) {
+	s, span := s.startSpan("CreatePage")
+	defer span.End()
+
+	if s.config.NotionDatabaseID == "" {
+		return nil, fmt.Errorf("a database ID is required to create pages")
+	}
+
+	payload := map[string]interface{}{
+		"parent":     map[string]string{"database_id": s.config.NotionDatabaseID},
+		"properties": properties,
+	}
+	if len(children) > 0 {
+		payload["children"] = children
+	}
+
+	var page models.NotionPage
+	if err := s.doRequest("POST", s.config.NotionAPIURL+"/pages", payload, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// UpdatePageProperties updates the properties of a page
+func (s *NotionService) UpdatePageProperties(pageID string, properties map[string]interface{}) error {
+	s, span := s.startSpan("UpdatePageProperties", attribute.String("notion.page_id", pageID))
+	defer span.End()
+
+	url := fmt.Sprintf("%s/pages/%s", s.config.NotionAPIURL, pageID)
+	payload := map[string]interface{}{"properties": properties}
+	return s.doRequest("PATCH", url, payload, nil)
+}
+
+// AppendBlockChildren appends child blocks to a page or block
+func (s *NotionService) AppendBlockChildren(blockID string, children []interface{}) error {
+	s, span := s.startSpan("AppendBlockChildren", attribute.String("notion.block_id", blockID))
+	defer span.End()
+
+	url := fmt.Sprintf("%s/blocks/%s/children", s.config.NotionAPIURL, blockID)
+	payload := map[string]interface{}{"children": children}
+	return s.doRequest("PATCH", url, payload, nil)
+}
+
+// UpdateTableRow replaces the cells of a table_row block
+func (s *NotionService) UpdateTableRow(blockID string, cells []string) error {
+	s, span := s.startSpan("UpdateTableRow", attribute.String("notion.block_id", blockID))
+	defer span.End()
+
+	url := fmt.Sprintf("%s/blocks/%s", s.config.NotionAPIURL, blockID)
+	payload := map[string]interface{}{
+		"table_row": map[string]interface{}{"cells": richTextCells(cells)},
+	}
+	return s.doRequest("PATCH", url, payload, nil)
+}
+
+// DeleteBlock archives a block
+func (s *NotionService) DeleteBlock(blockID string) error {
+	s, span := s.startSpan("DeleteBlock", attribute.String("notion.block_id", blockID))
+	defer span.End()
+
+	url := fmt.Sprintf("%s/blocks/%s", s.config.NotionAPIURL, blockID)
+	return s.doRequest("DELETE", url, nil, nil)
+}
+
 // Helper methods
 
+// doRequest sends a JSON payload (if any) and decodes the response into out (if not nil)
+func (s *NotionService) doRequest(method, url string, payload, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		reqBody, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewBuffer(reqBody)
+	}
+
+	req, err := http.NewRequestWithContext(s.ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	s.setHeaders(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return notionAPIError(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func (s *NotionService) testCaseProperties(update models.TestCaseUpdate) map[string]interface{} {
+	properties := make(map[string]interface{})
+
+	if update.Title != nil {
+		properties[s.schema.TitleProperty] = map[string]interface{}{
+			"title": richTextPayload(*update.Title),
+		}
+	}
+
+	if update.Status != nil && s.schema.StatusProperty != "" {
+		var value interface{}
+		if *update.Status != "" {
+			value = map[string]string{"name": *update.Status}
+		}
+		properties[s.schema.StatusProperty] = map[string]interface{}{
+			s.schema.StatusType: value,
+		}
+	}
+
+	if update.TestDate != nil && s.schema.DateProperty != "" {
+		var value interface{}
+		if *update.TestDate != "" {
+			value = map[string]string{"start": *update.TestDate}
+		}
+		properties[s.schema.DateProperty] = map[string]interface{}{
+			"date": value,
+		}
+	}
+
+	if update.Tags != nil && s.schema.TagsProperty != "" {
+		options := []map[string]string{}
+		for _, tag := range *update.Tags {
+			options = append(options, map[string]string{"name": tag})
+		}
+		properties[s.schema.TagsProperty] = map[string]interface{}{
+			"multi_select": options,
+		}
+	}
+
+	return properties
+}
+
+// notionAPIError describes a failed Notion response. Rate limits wrap
+// ErrNotionRateLimited and server errors ErrNotionUnavailable.
+func notionAPIError(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("%w: status %d, body: %s", ErrNotionRateLimited, resp.StatusCode, string(body))
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return fmt.Errorf("%w: status %d, body: %s", ErrNotionUnavailable, resp.StatusCode, string(body))
+	}
+	return fmt.Errorf("notion API error: status %d, body: %s", resp.StatusCode, string(body))
+}
+
 func (s *NotionService) setHeaders(req *http.Request) {
 	req.Header.Set("Authorization", "Bearer "+s.config.NotionAPIKey)
 	req.Header.Set("Content-Type", "application/json")
@@ -280,55 +779,64 @@ func (s *NotionService) setHeaders(req *http.Request) {
 func (s *NotionService) extractTestCases(pages []models.NotionPage) []models.TestCaseResponse {
 	var testCases []models.TestCaseResponse
 
-	// Regex to match TC_ followed by numbers
-	tcRegex := regexp.MustCompile(`TC_(\d+)`)
-	fmt.Println("pages", pages)
-
 	for _, page := range pages {
-		// Extract Test Case Name property
-		if testCaseNameProp, exists := page.Properties["Test Case Name"]; exists {
-			if titleProp, ok := testCaseNameProp.(map[string]interface{}); ok {
-				if titleArray, exists := titleProp["title"].([]interface{}); exists && len(titleArray) > 0 {
-					if firstTitle, ok := titleArray[0].(map[string]interface{}); ok {
-						if plainText, exists := firstTitle["plain_text"].(string); exists {
-							// Check if it starts with TC_ and extract the key
-							if matches := tcRegex.FindStringSubmatch(plainText); matches != nil {
-								testCaseKey := matches[1] // Extract the number part
-
-								// Extract other properties
-								status := s.extractStatus(page.Properties)
-								testDate := s.extractTestDate(page.Properties)
-
-								testCase := models.TestCaseResponse{
-									TestCaseKey: testCaseKey,
-									PageID:      page.ID,
-									Title:       plainText,
-									Status:      status,
-									TestDate:    testDate,
-									URL:         page.URL,
-									LastEdited:  page.LastEditedTime,
-								}
-
-								testCases = append(testCases, testCase)
-							}
-						}
-					}
-				}
-			}
+		// Extract the title property and match the configured key pattern
+		title := s.extractTitle(page.Properties)
+		if title == "" {
+			continue
 		}
+
+		testCaseKey := s.schema.ExtractKey(title)
+		if testCaseKey == "" {
+			continue
+		}
+
+		testCase := models.TestCaseResponse{
+			Project:      s.config.ProjectName,
+			TestCaseKey:  testCaseKey,
+			PageID:       page.ID,
+			Title:        title,
+			Status:       s.extractStatus(page.Properties),
+			TestDate:     s.extractTestDate(page.Properties),
+			Tags:         s.extractTags(page.Properties),
+			Assignees:    s.extractAssignees(page.Properties),
+			URL:          page.URL,
+			LastEdited:   page.LastEditedTime,
+			LastEditedBy: page.LastEditedBy.ID,
+		}
+
+		testCases = append(testCases, testCase)
 	}
 
-	fmt.Printf("Extracted %d test cases\n", len(testCases))
+	slog.DebugContext(s.ctx, "extracted test cases", slog.Int("pages", len(pages)), slog.Int("test_cases", len(testCases)))
 
 	return testCases
 }
 
+func (s *NotionService) extractTitle(properties map[string]interface{}) string {
+	if titleProp, exists := properties[s.schema.TitleProperty]; exists {
+		if titleMap, ok := titleProp.(map[string]interface{}); ok {
+			if titleArray, exists := titleMap["title"].([]interface{}); exists && len(titleArray) > 0 {
+				if firstTitle, ok := titleArray[0].(map[string]interface{}); ok {
+					if plainText, exists := firstTitle["plain_text"].(string); exists {
+						return plainText
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// extractStatus reads the status property, which may be a status or a select property
 func (s *NotionService) extractStatus(properties map[string]interface{}) string {
-	if statusProp, exists := properties["Status"]; exists {
+	if statusProp, exists := properties[s.schema.StatusProperty]; exists {
 		if statusMap, ok := statusProp.(map[string]interface{}); ok {
-			if status, exists := statusMap["status"].(map[string]interface{}); exists {
-				if name, exists := status["name"].(string); exists {
-					return name
+			for _, propType := range []string{"status", "select"} {
+				if status, exists := statusMap[propType].(map[string]interface{}); exists {
+					if name, exists := status["name"].(string); exists {
+						return name
+					}
 				}
 			}
 		}
@@ -337,7 +845,7 @@ func (s *NotionService) extractStatus(properties map[string]interface{}) string
 }
 
 func (s *NotionService) extractTestDate(properties map[string]interface{}) string {
-	if dateProp, exists := properties["Test Date"]; exists {
+	if dateProp, exists := properties[s.schema.DateProperty]; exists {
 		if dateMap, ok := dateProp.(map[string]interface{}); ok {
 			if date, exists := dateMap["date"].(map[string]interface{}); exists {
 				if start, exists := date["start"].(string); exists {
@@ -349,6 +857,46 @@ func (s *NotionService) extractTestDate(properties map[string]interface{}) strin
 	return ""
 }
 
+func (s *NotionService) extractTags(properties map[string]interface{}) []string {
+	var tags []string
+	if tagsProp, exists := properties[s.schema.TagsProperty]; exists {
+		if tagsMap, ok := tagsProp.(map[string]interface{}); ok {
+			if options, exists := tagsMap["multi_select"].([]interface{}); exists {
+				for _, option := range options {
+					if optionMap, ok := option.(map[string]interface{}); ok {
+						if name, exists := optionMap["name"].(string); exists {
+							tags = append(tags, name)
+						}
+					}
+				}
+			}
+		}
+	}
+	return tags
+}
+
+// extractAssignees reads the names of a people property, falling back to user IDs
+// when the integration cannot see user names
+func (s *NotionService) extractAssignees(properties map[string]interface{}) []string {
+	var assignees []string
+	if peopleProp, exists := properties[s.schema.AssigneeProperty]; exists {
+		if peopleMap, ok := peopleProp.(map[string]interface{}); ok {
+			if people, exists := peopleMap["people"].([]interface{}); exists {
+				for _, person := range people {
+					if personMap, ok := person.(map[string]interface{}); ok {
+						if name, exists := personMap["name"].(string); exists && name != "" {
+							assignees = append(assignees, name)
+						} else if id, exists := personMap["id"].(string); exists {
+							assignees = append(assignees, id)
+						}
+					}
+				}
+			}
+		}
+	}
+	return assignees
+}
+
 func (s *NotionService) convertToBlockResponses(blocks []models.NotionBlock) []models.BlockResponse {
 	var blockResponses []models.BlockResponse
 
@@ -380,10 +928,18 @@ func (s *NotionService) convertToBlockResponse(block models.NotionBlock) *models
 		if block.Paragraph != nil {
 			blockResp.Content = s.extractRichTextContent(block.Paragraph.RichText)
 		}
+	case "heading_1":
+		if block.Heading1 != nil {
+			blockResp.Content = s.extractRichTextContent(block.Heading1.RichText)
+		}
 	case "heading_2":
 		if block.Heading2 != nil {
 			blockResp.Content = s.extractRichTextContent(block.Heading2.RichText)
 		}
+	case "heading_3":
+		if block.Heading3 != nil {
+			blockResp.Content = s.extractRichTextContent(block.Heading3.RichText)
+		}
 	}
 
 	return blockResp
@@ -396,3 +952,48 @@ func (s *NotionService) extractRichTextContent(richText []models.RichText) strin
 	}
 	return strings.Join(content, "")
 }
+
+// TablePayload builds a table block with a header row and the given rows
+func TablePayload(header []string, rows [][]string) map[string]interface{} {
+	children := []interface{}{tableRowPayload(header)}
+	for _, row := range rows {
+		children = append(children, tableRowPayload(row))
+	}
+
+	return map[string]interface{}{
+		"object": "block",
+		"type":   "table",
+		"table": map[string]interface{}{
+			"table_width":       len(header),
+			"has_column_header": true,
+			"has_row_header":    false,
+			"children":          children,
+		},
+	}
+}
+
+func tableRowPayload(cells []string) map[string]interface{} {
+	return map[string]interface{}{
+		"object":    "block",
+		"type":      "table_row",
+		"table_row": map[string]interface{}{"cells": richTextCells(cells)},
+	}
+}
+
+func richTextCells(cells []string) [][]map[string]interface{} {
+	result := make([][]map[string]interface{}, len(cells))
+	for i, cell := range cells {
+		result[i] = richTextPayload(cell)
+	}
+	return result
+}
+
+func richTextPayload(content string) []map[string]interface{} {
+	if content == "" {
+		return []map[string]interface{}{}
+	}
+	return []map[string]interface{}{{
+		"type": "text",
+		"text": map[string]string{"content": content},
+	}}
+}
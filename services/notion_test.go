@@ -0,0 +1,163 @@
+package services
+
+import (
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSearchTestCasesFollowsCursors(t *testing.T) {
+	page := func(key string) map[string]any {
+		return map[string]any{
+			"object": "page",
+			"id":     "page-" + key,
+			"properties": map[string]any{
+				"Test Case Name": map[string]any{
+					"type":  "title",
+					"title": []map[string]any{{"plain_text": "TC_" + key + " Login"}},
+				},
+			},
+		}
+	}
+	responses := map[string]map[string]any{
+		"":   {"results": []any{page("01001"), page("01002")}, "has_more": true, "next_cursor": "c1"},
+		"c1": {"results": []any{page("01003")}, "has_more": true, "next_cursor": "c2"},
+		"c2": {"results": []any{}, "has_more": false, "next_cursor": nil},
+	}
+
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			StartCursor string `json:"start_cursor"`
+			PageSize    int    `json:"page_size"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		if body.PageSize != notionPageSize {
+			t.Errorf("page_size = %d, want %d", body.PageSize, notionPageSize)
+		}
+		cursors = append(cursors, body.StartCursor)
+
+		response, ok := responses[body.StartCursor]
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown cursor %q", body.StartCursor), http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	for _, databaseID := range []string{"", "db"} {
+		cursors = nil
+		service := NewNotionService(&config.Config{NotionAPIURL: server.URL, NotionDatabaseID: databaseID})
+
+		testCases, err := service.SearchTestCases()
+		if err != nil {
+			t.Fatalf("SearchTestCases() error = %v", err)
+		}
+
+		var keys []string
+		for _, tc := range testCases {
+			keys = append(keys, tc.TestCaseKey)
+		}
+		if fmt.Sprint(keys) != "[01001 01002 01003]" {
+			t.Errorf("database %q: keys = %v, want every page", databaseID, keys)
+		}
+		if fmt.Sprint(cursors) != "[ c1 c2]" {
+			t.Errorf("database %q: cursors = %q, want [\"\" c1 c2]", databaseID, cursors)
+		}
+	}
+}
+
+func TestDetailedTestCasesKeepPageOrder(t *testing.T) {
+	keys := []string{"01001", "01002", "01003", "01004", "01005", "01006"}
+	var results []any
+	for _, key := range keys {
+		results = append(results, map[string]any{
+			"object": "page",
+			"id":     "page-" + key,
+			"properties": map[string]any{
+				"Test Case Name": map[string]any{
+					"type":  "title",
+					"title": []map[string]any{{"plain_text": "TC_" + key + " Login"}},
+				},
+			},
+		})
+	}
+
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/search" {
+			json.NewEncoder(w).Encode(map[string]any{"results": results})
+			return
+		}
+
+		mu.Lock()
+		active++
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+
+		// Earlier pages answer later, so reads finish out of order
+		var index int
+		fmt.Sscanf(r.URL.Path, "/blocks/page-0100%d/children", &index)
+		time.Sleep(time.Duration(len(keys)-index) * 5 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{"results": []any{}})
+	}))
+	defer server.Close()
+
+	service := NewNotionService(&config.Config{NotionAPIURL: server.URL})
+	want := fmt.Sprint(keys)
+
+	detailed, _, err := service.QueryDetailedTestCases(models.TestCaseQuery{Sort: SortTestCaseKey})
+	if err != nil {
+		t.Fatalf("QueryDetailedTestCases() error = %v", err)
+	}
+	var got []string
+	for _, tc := range detailed {
+		got = append(got, tc.TestCaseKey)
+	}
+	if fmt.Sprint(got) != want {
+		t.Errorf("QueryDetailedTestCases() keys = %v, want %v", got, want)
+	}
+
+	got = nil
+	var completed []int
+	_, err = service.StreamDetailedTestCases(models.TestCaseQuery{Sort: SortTestCaseKey}, func(tc models.DetailedTestCaseResponse, progress models.StreamProgress) error {
+		got = append(got, tc.TestCaseKey)
+		completed = append(completed, progress.Completed)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamDetailedTestCases() error = %v", err)
+	}
+	if fmt.Sprint(got) != want || fmt.Sprint(completed) != "[1 2 3 4 5 6]" {
+		t.Errorf("StreamDetailedTestCases() keys = %v, progress %v, want %v in order", got, completed, want)
+	}
+
+	stop := errors.New("stop")
+	sent := 0
+	_, err = service.StreamDetailedTestCases(models.TestCaseQuery{Sort: SortTestCaseKey}, func(models.DetailedTestCaseResponse, models.StreamProgress) error {
+		sent++
+		return stop
+	})
+	if !errors.Is(err, stop) || sent != 1 {
+		t.Errorf("StreamDetailedTestCases() = %v after %d sends, want the send error after 1", err, sent)
+	}
+
+	if maxActive < 2 || maxActive > maxConcurrentReads {
+		t.Errorf("concurrent reads = %d, want between 2 and %d", maxActive, maxConcurrentReads)
+	}
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrProjectNotFound = errors.New("project not found")
+
+// Project is a named Notion workspace and database with its own schema
+type Project struct {
+	Name          string
+	DatabaseID    string
+	NotionService *NotionService
+}
+
+// ProjectRegistry holds the projects served by this deployment
+type ProjectRegistry struct {
+	projects       map[string]*Project
+	order          []string
+	defaultProject string
+}
+
+// NewProjectRegistry builds one project per entry of cfg.ProjectsFile.
+// Without a projects file, a single "default" project is built from cfg.
+func NewProjectRegistry(cfg *config.Config) (*ProjectRegistry, error) {
+	file, err := config.LoadProjects(cfg.ProjectsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ProjectRegistry{projects: make(map[string]*Project)}
+
+	if file == nil {
+		projectCfg := *cfg
+		projectCfg.ProjectName = config.DefaultProjectName
+		r.add(&projectCfg)
+		r.defaultProject = config.DefaultProjectName
+		return r, nil
+	}
+
+	for _, p := range file.Projects {
+		projectCfg := *cfg
+		projectCfg.ProjectName = p.Name
+		projectCfg.NotionAPIKey = p.Token()
+		projectCfg.NotionDatabaseID = p.DatabaseID
+
+		// A schema file named by a project must exist, unlike SCHEMA_FILE
+		if p.SchemaFile != "" {
+			schema, err := config.LoadSchemaFile(p.SchemaFile)
+			if err != nil {
+				return nil, fmt.Errorf("project %s: %w", p.Name, err)
+			}
+			projectCfg.Schema = schema
+		}
+
+		r.add(&projectCfg)
+	}
+
+	r.defaultProject = file.DefaultProject
+	if r.defaultProject == "" {
+		r.defaultProject = r.order[0]
+	}
+
+	return r, nil
+}
+
+// Get returns a project by name
+func (r *ProjectRegistry) Get(name string) (*Project, error) {
+	project, ok := r.projects[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, name)
+	}
+	return project, nil
+}
+
+// Default returns the project that serves the unscoped routes
+func (r *ProjectRegistry) Default() *Project {
+	return r.projects[r.defaultProject]
+}
+
+// List returns all projects in definition order
+func (r *ProjectRegistry) List() []models.ProjectInfo {
+	infos := make([]models.ProjectInfo, 0, len(r.order))
+	for _, name := range r.order {
+		infos = append(infos, models.ProjectInfo{
+			Name:       name,
+			DatabaseID: r.projects[name].DatabaseID,
+			Default:    name == r.defaultProject,
+		})
+	}
+	return infos
+}
+
+// SearchAllTestCases merges the test cases of every project, ordered by project then key
+func (r *ProjectRegistry) SearchAllTestCases(ctx context.Context) ([]models.TestCaseResponse, error) {
+	merged := []models.TestCaseResponse{}
+	for _, name := range r.order {
+		testCases, err := r.projects[name].NotionService.WithContext(ctx).SearchTestCases()
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", name, err)
+		}
+		merged = append(merged, testCases...)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Project != merged[j].Project {
+			return merged[i].Project < merged[j].Project
+		}
+		return compareKeys(merged[i].TestCaseKey, merged[j].TestCaseKey) < 0
+	})
+
+	return merged, nil
+}
+
+// Helper methods
+
+func (r *ProjectRegistry) add(cfg *config.Config) {
+	r.projects[cfg.ProjectName] = &Project{
+		Name:          cfg.ProjectName,
+		DatabaseID:    cfg.NotionDatabaseID,
+		NotionService: NewNotionService(cfg),
+	}
+	r.order = append(r.order, cfg.ProjectName)
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"demo-notion-api/models"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidQuery = errors.New("invalid query")
+	// ErrIncompleteTestCases reports test cases whose tables could not all be read
+	ErrIncompleteTestCases = errors.New("test cases could not be read completely")
+)
+
+// Sort fields of test case listings
+const (
+	SortTestCaseKey = "test_case_key"
+	SortTitle       = "title"
+	SortStatus      = "status"
+	SortTestDate    = "test_date"
+	SortTags        = "tags"
+	SortAssignees   = "assignees"
+	SortLastEdited  = "last_edited"
+	SortProject     = "project"
+)
+
+// DefaultSort keeps the order in which Notion returns test cases
+const DefaultSort = SortLastEdited
+
+var sortComparators = map[string]func(a, b models.TestCaseResponse) int{
+	SortTestCaseKey: func(a, b models.TestCaseResponse) int { return compareKeys(a.TestCaseKey, b.TestCaseKey) },
+	SortTitle:       func(a, b models.TestCaseResponse) int { return compareFold(a.Title, b.Title) },
+	SortStatus:      func(a, b models.TestCaseResponse) int { return compareFold(a.Status, b.Status) },
+	SortTestDate:    func(a, b models.TestCaseResponse) int { return strings.Compare(a.TestDate, b.TestDate) },
+	SortTags: func(a, b models.TestCaseResponse) int {
+		return compareFold(strings.Join(a.Tags, ","), strings.Join(b.Tags, ","))
+	},
+	SortAssignees: func(a, b models.TestCaseResponse) int {
+		return compareFold(strings.Join(a.Assignees, ","), strings.Join(b.Assignees, ","))
+	},
+	SortLastEdited: func(a, b models.TestCaseResponse) int { return a.LastEdited.Compare(b.LastEdited) },
+	SortProject:    func(a, b models.TestCaseResponse) int { return strings.Compare(a.Project, b.Project) },
+}
+
+// queryCursor is the position after the last test case of a page. Pages are
+// keyset based, so test cases added or removed meanwhile do not shift them.
+type queryCursor struct {
+	Sort       string                  `json:"s"`
+	Descending bool                    `json:"d,omitempty"`
+	After      models.TestCaseResponse `json:"a"`
+}
+
+// QueryTestCases searches test cases and applies the filters, sort and page of q
+func (s *NotionService) QueryTestCases(q models.TestCaseQuery) ([]models.TestCaseResponse, models.Pagination, error) {
+	s, span := s.startSpan("QueryTestCases")
+	defer span.End()
+
+	if err := ValidateQuery(q); err != nil {
+		return nil, models.Pagination{}, err
+	}
+
+	testCases, err := s.SearchTestCases()
+	if err != nil {
+		return nil, models.Pagination{}, err
+	}
+
+	return ApplyQuery(testCases, q)
+}
+
+// QueryDetailedTestCases pages the test cases like QueryTestCases and reads the
+// table data of the test cases on the page only
+func (s *NotionService) QueryDetailedTestCases(q models.TestCaseQuery) ([]models.DetailedTestCaseResponse, models.Pagination, error) {
+	s, span := s.startSpan("QueryDetailedTestCases")
+	defer span.End()
+
+	testCases, pagination, err := s.QueryTestCases(q)
+	if err != nil {
+		return nil, pagination, err
+	}
+
+	detailed := make([]models.DetailedTestCaseResponse, len(testCases))
+	s.readDetailedTestCases(testCases, func(i int, tc models.DetailedTestCaseResponse) error {
+		detailed[i] = tc
+		return nil
+	})
+	return detailed, pagination, nil
+}
+
+// CheckComplete returns ErrIncompleteTestCases with the first error when any
+// of the detailed test cases has errors
+func CheckComplete(detailed []models.DetailedTestCaseResponse) error {
+	failed := 0
+	var first models.TestCaseError
+	for _, tc := range detailed {
+		if len(tc.Errors) == 0 {
+			continue
+		}
+		if failed == 0 {
+			first = tc.Errors[0]
+		}
+		failed++
+	}
+
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %d of %d, first %s: %s", ErrIncompleteTestCases, failed, len(detailed), first.TestCaseKey, first.Message)
+}
+
+// StreamDetailedTestCases pages the test cases like QueryTestCases and passes
+// each of them to send in page order as soon as its table data is read,
+// together with the progress through the page. It stops at the first error returned by send.
+func (s *NotionService) StreamDetailedTestCases(q models.TestCaseQuery, send func(models.DetailedTestCaseResponse, models.StreamProgress) error) (models.Pagination, error) {
+	s, span := s.startSpan("StreamDetailedTestCases")
+	defer span.End()
+
+	testCases, pagination, err := s.QueryTestCases(q)
+	if err != nil {
+		return pagination, err
+	}
+
+	err = s.readDetailedTestCases(testCases, func(i int, tc models.DetailedTestCaseResponse) error {
+		return send(tc, models.StreamProgress{Completed: i + 1, Total: len(testCases)})
+	})
+	return pagination, err
+}
+
+// ValidateQuery checks the sort field, dates and cursor of q
+func ValidateQuery(q models.TestCaseQuery) error {
+	if _, ok := sortComparators[sortField(q)]; !ok {
+		return fmt.Errorf("%w: unknown sort field %q", ErrInvalidQuery, q.Sort)
+	}
+
+	for name, value := range map[string]string{"test_date_from": q.TestDateFrom, "test_date_to": q.TestDateTo} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return fmt.Errorf("%w: %s must be a date in YYYY-MM-DD format", ErrInvalidQuery, name)
+		}
+	}
+
+	if q.Limit < 0 {
+		return fmt.Errorf("%w: limit must not be negative", ErrInvalidQuery)
+	}
+
+	if q.Cursor != "" {
+		if _, err := decodeCursor(q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyQuery filters, sorts and pages test cases. Ties in the sort field are
+// broken by project, key and page ID so that every page boundary is exact.
+func ApplyQuery(testCases []models.TestCaseResponse, q models.TestCaseQuery) ([]models.TestCaseResponse, models.Pagination, error) {
+	if err := ValidateQuery(q); err != nil {
+		return nil, models.Pagination{}, err
+	}
+
+	matched := []models.TestCaseResponse{}
+	for _, tc := range testCases {
+		if queryMatches(q, tc) {
+			matched = append(matched, tc)
+		}
+	}
+
+	compare := queryComparator(q)
+	sort.SliceStable(matched, func(i, j int) bool {
+		return compare(matched[i], matched[j]) < 0
+	})
+
+	pagination := models.Pagination{Total: len(matched), Limit: q.Limit}
+
+	if q.Cursor != "" {
+		cursor, _ := decodeCursor(q)
+		start := sort.Search(len(matched), func(i int) bool {
+			return compare(matched[i], cursor.After) > 0
+		})
+		matched = matched[start:]
+	}
+
+	if q.Limit > 0 && len(matched) > q.Limit {
+		matched = matched[:q.Limit]
+		pagination.HasMore = true
+		pagination.NextCursor = encodeCursor(q, matched[len(matched)-1])
+	}
+
+	return matched, pagination, nil
+}
+
+// Helper functions
+
+func queryMatches(q models.TestCaseQuery, tc models.TestCaseResponse) bool {
+	if len(q.Statuses) > 0 && !containsFold(q.Statuses, tc.Status) {
+		return false
+	}
+
+	date := tc.TestDate
+	if len(date) > len("2006-01-02") {
+		date = date[:len("2006-01-02")]
+	}
+	if q.TestDateFrom != "" && (date == "" || date < q.TestDateFrom) {
+		return false
+	}
+	if q.TestDateTo != "" && (date == "" || date > q.TestDateTo) {
+		return false
+	}
+
+	if q.KeyPrefix != "" && !strings.HasPrefix(tc.TestCaseKey, q.KeyPrefix) {
+		return false
+	}
+	if q.KeyFrom != "" && compareKeys(tc.TestCaseKey, q.KeyFrom) < 0 {
+		return false
+	}
+	if q.KeyTo != "" && compareKeys(tc.TestCaseKey, q.KeyTo) > 0 {
+		return false
+	}
+
+	if q.Title != "" && !strings.Contains(strings.ToLower(tc.Title), strings.ToLower(q.Title)) {
+		return false
+	}
+
+	for _, tag := range q.Tags {
+		if !containsFold(tc.Tags, tag) {
+			return false
+		}
+	}
+
+	if q.Assignee != "" && !containsFold(tc.Assignees, q.Assignee) {
+		return false
+	}
+
+	return true
+}
+
+func queryComparator(q models.TestCaseQuery) func(a, b models.TestCaseResponse) int {
+	byField := sortComparators[sortField(q)]
+	return func(a, b models.TestCaseResponse) int {
+		result := byField(a, b)
+		if result == 0 {
+			result = strings.Compare(a.Project, b.Project)
+		}
+		if result == 0 {
+			result = compareKeys(a.TestCaseKey, b.TestCaseKey)
+		}
+		if result == 0 {
+			result = strings.Compare(a.PageID, b.PageID)
+		}
+		if q.Descending {
+			return -result
+		}
+		return result
+	}
+}
+
+func sortField(q models.TestCaseQuery) string {
+	if q.Sort == "" {
+		return DefaultSort
+	}
+	return q.Sort
+}
+
+func encodeCursor(q models.TestCaseQuery, last models.TestCaseResponse) string {
+	last.URL = ""
+	data, _ := json.Marshal(queryCursor{Sort: sortField(q), Descending: q.Descending, After: last})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(q models.TestCaseQuery) (*queryCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+
+	var cursor queryCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	if cursor.Sort != sortField(q) || cursor.Descending != q.Descending {
+		return nil, fmt.Errorf("%w: cursor belongs to a different sort order", ErrInvalidQuery)
+	}
+	return &cursor, nil
+}
+
+func compareFold(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
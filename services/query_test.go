@@ -0,0 +1,174 @@
+package services
+
+import (
+	"demo-notion-api/models"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestCompareKeys(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2", "10", -1},
+		{"01001", "1001", -1},
+		{"1001", "01001", 1},
+		{"01001", "01001", 0},
+		{"10", "A", -1},
+		{"A", "10", 1},
+		{"A-10", "A-2", -1},
+		{"", "1", 1},
+	}
+
+	for _, tt := range tests {
+		if got := compareKeys(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareKeys(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCompareKeysIsTotalOrder(t *testing.T) {
+	keys := []string{"10", "9", "09", "A", "1A", "B2", "", "-1", "100"}
+
+	for _, a := range keys {
+		for _, b := range keys {
+			if compareKeys(a, b) != -compareKeys(b, a) {
+				t.Errorf("compareKeys(%q, %q) is not antisymmetric", a, b)
+			}
+			for _, c := range keys {
+				if compareKeys(a, b) < 0 && compareKeys(b, c) < 0 && compareKeys(a, c) >= 0 {
+					t.Errorf("%q < %q < %q but not %q < %q", a, b, c, a, c)
+				}
+			}
+		}
+	}
+
+	sorted := append([]string{}, keys...)
+	sort.Slice(sorted, func(i, j int) bool { return compareKeys(sorted[i], sorted[j]) < 0 })
+	want := []string{"-1", "09", "9", "10", "100", "", "1A", "A", "B2"}
+	if !reflect.DeepEqual(sorted, want) {
+		t.Errorf("sorted = %q, want %q", sorted, want)
+	}
+}
+
+func queryTestCases() []models.TestCaseResponse {
+	edited := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []models.TestCaseResponse{
+		{TestCaseKey: "10", PageID: "p10", Title: "Logout", Status: "Passed", TestDate: "2026-01-10", Tags: []string{"smoke"}, LastEdited: edited},
+		{TestCaseKey: "2", PageID: "p2", Title: "Login", Status: "Failed", TestDate: "2026-01-02T09:00:00.000+07:00", Assignees: []string{"Ann"}, LastEdited: edited.Add(time.Hour)},
+		{TestCaseKey: "1", PageID: "p1", Title: "Sign up", Status: "passed", Tags: []string{"Smoke", "auth"}, LastEdited: edited},
+		{TestCaseKey: "X1", PageID: "px", Title: "Profile", Status: "Blocked", TestDate: "2026-02-01", LastEdited: edited.Add(-time.Hour)},
+	}
+}
+
+func pageIDs(testCases []models.TestCaseResponse) []string {
+	ids := []string{}
+	for _, tc := range testCases {
+		ids = append(ids, tc.PageID)
+	}
+	return ids
+}
+
+func TestApplyQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query models.TestCaseQuery
+		want  []string
+	}{
+		{name: "default sort by last edited", query: models.TestCaseQuery{}, want: []string{"px", "p1", "p10", "p2"}},
+		{name: "status ignores case", query: models.TestCaseQuery{Statuses: []string{"PASSED"}}, want: []string{"p1", "p10"}},
+		{name: "test date range uses the day", query: models.TestCaseQuery{TestDateFrom: "2026-01-02", TestDateTo: "2026-01-10"}, want: []string{"p10", "p2"}},
+		{name: "key range is numeric", query: models.TestCaseQuery{KeyFrom: "2", KeyTo: "10", Sort: SortTestCaseKey}, want: []string{"p2", "p10"}},
+		{name: "key prefix", query: models.TestCaseQuery{KeyPrefix: "1", Sort: SortTestCaseKey}, want: []string{"p1", "p10"}},
+		{name: "title contains", query: models.TestCaseQuery{Title: "LOG"}, want: []string{"p10", "p2"}},
+		{name: "every tag", query: models.TestCaseQuery{Tags: []string{"smoke", "AUTH"}}, want: []string{"p1"}},
+		{name: "assignee", query: models.TestCaseQuery{Assignee: "ann"}, want: []string{"p2"}},
+		{name: "keys sort numbers first", query: models.TestCaseQuery{Sort: SortTestCaseKey}, want: []string{"p1", "p2", "p10", "px"}},
+		{name: "descending", query: models.TestCaseQuery{Sort: SortTestCaseKey, Descending: true}, want: []string{"px", "p10", "p2", "p1"}},
+		{name: "title", query: models.TestCaseQuery{Sort: SortTitle}, want: []string{"p2", "p10", "px", "p1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pagination, err := ApplyQuery(queryTestCases(), tt.query)
+			if err != nil {
+				t.Fatalf("ApplyQuery() error = %v", err)
+			}
+			if !reflect.DeepEqual(pageIDs(got), tt.want) {
+				t.Errorf("page IDs = %q, want %q", pageIDs(got), tt.want)
+			}
+			if pagination.Total != len(tt.want) || pagination.HasMore {
+				t.Errorf("pagination = %+v, want a single page of %d", pagination, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestApplyQueryInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query models.TestCaseQuery
+	}{
+		{name: "unknown sort field", query: models.TestCaseQuery{Sort: "priority"}},
+		{name: "bad date", query: models.TestCaseQuery{TestDateFrom: "01/02/2026"}},
+		{name: "negative limit", query: models.TestCaseQuery{Limit: -1}},
+		{name: "malformed cursor", query: models.TestCaseQuery{Cursor: "not a cursor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ApplyQuery(queryTestCases(), tt.query); !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("ApplyQuery() error = %v, want ErrInvalidQuery", err)
+			}
+		})
+	}
+}
+
+func TestApplyQueryCursorRoundTrip(t *testing.T) {
+	for _, sortField := range []string{SortTestCaseKey, SortLastEdited, SortTags, SortStatus} {
+		for _, descending := range []bool{false, true} {
+			q := models.TestCaseQuery{Sort: sortField, Descending: descending}
+			all, _, err := ApplyQuery(queryTestCases(), q)
+			if err != nil {
+				t.Fatalf("ApplyQuery() error = %v", err)
+			}
+
+			var paged []models.TestCaseResponse
+			q.Limit = 1
+			for page := 0; ; page++ {
+				if page > len(all) {
+					t.Fatalf("sort %s: paging does not end", sortField)
+				}
+				got, pagination, err := ApplyQuery(queryTestCases(), q)
+				if err != nil {
+					t.Fatalf("sort %s: page %d: %v", sortField, page, err)
+				}
+				paged = append(paged, got...)
+				if !pagination.HasMore {
+					break
+				}
+				q.Cursor = pagination.NextCursor
+			}
+
+			if !reflect.DeepEqual(pageIDs(paged), pageIDs(all)) {
+				t.Errorf("sort %s descending=%v: pages = %q, want %q", sortField, descending, pageIDs(paged), pageIDs(all))
+			}
+		}
+	}
+}
+
+func TestApplyQueryCursorOfOtherSort(t *testing.T) {
+	_, pagination, err := ApplyQuery(queryTestCases(), models.TestCaseQuery{Sort: SortTitle, Limit: 1})
+	if err != nil {
+		t.Fatalf("ApplyQuery() error = %v", err)
+	}
+
+	_, _, err = ApplyQuery(queryTestCases(), models.TestCaseQuery{Sort: SortTestCaseKey, Limit: 1, Cursor: pagination.NextCursor})
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("ApplyQuery() error = %v, want ErrInvalidQuery", err)
+	}
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"demo-notion-api/config"
+	"demo-notion-api/metrics"
+	"demo-notion-api/models"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// readinessCheckTimeout bounds each Notion call of a readiness check, so that
+// a hanging upstream fails the probe instead of timing it out
+const readinessCheckTimeout = 5 * time.Second
+
+// ReadinessService checks that every project can reach Notion and that the
+// sync mirror is fresh
+type ReadinessService struct {
+	projects   *ProjectRegistry
+	sync       *SyncService
+	maxSyncAge time.Duration
+	cacheTTL   time.Duration
+
+	mu     sync.Mutex
+	cached *models.ReadinessReport
+}
+
+// NewReadinessService reads the allowed age of the last sync from
+// cfg.ReadyMaxSyncAge and how long a report is reused from cfg.ReadyCacheTTL
+func NewReadinessService(cfg *config.Config, projects *ProjectRegistry, sync *SyncService) (*ReadinessService, error) {
+	maxSyncAge, err := time.ParseDuration(cfg.ReadyMaxSyncAge)
+	if err != nil || maxSyncAge <= 0 {
+		return nil, fmt.Errorf("invalid maximum sync age %q", cfg.ReadyMaxSyncAge)
+	}
+	cacheTTL, err := time.ParseDuration(cfg.ReadyCacheTTL)
+	if err != nil || cacheTTL < 0 {
+		return nil, fmt.Errorf("invalid readiness cache TTL %q", cfg.ReadyCacheTTL)
+	}
+
+	return &ReadinessService{
+		projects:   projects,
+		sync:       sync,
+		maxSyncAge: maxSyncAge,
+		cacheTTL:   cacheTTL,
+	}, nil
+}
+
+// Check returns the last report while it is younger than the cache TTL, so
+// that frequent probes do not spend the Notion quota, and otherwise runs the
+// checks again. Concurrent callers wait for one run.
+func (s *ReadinessService) Check(ctx context.Context) models.ReadinessReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hit := s.cached != nil && time.Since(s.cached.CheckedAt) < s.cacheTTL
+	metrics.CacheLookup("readiness", hit)
+	if hit {
+		return *s.cached
+	}
+
+	// The report is shared, so a caller that goes away must not cut it short
+	report := s.check(context.WithoutCancel(ctx))
+	s.cached = &report
+	return report
+}
+
+// Helper methods
+
+// check runs every component check of every project. The report is not ready
+// when any check failed.
+func (s *ReadinessService) check(ctx context.Context) models.ReadinessReport {
+	report := models.ReadinessReport{
+		Status:     models.ReadinessReady,
+		CheckedAt:  time.Now().UTC(),
+		Components: []models.ComponentCheck{},
+	}
+
+	lastSynced := s.sync.LastSynced()
+	for _, info := range s.projects.List() {
+		project, err := s.projects.Get(info.Name)
+		if err != nil {
+			continue
+		}
+
+		report.Components = append(report.Components, s.checkProject(ctx, project)...)
+		report.Components = append(report.Components, s.checkSync(project.Name, lastSynced))
+	}
+
+	for _, check := range report.Components {
+		if check.Status == models.CheckFail {
+			report.Status = models.ReadinessNotReady
+			break
+		}
+	}
+
+	return report
+}
+
+// checkProject checks the API key, a call with it and the database of a
+// project. Checks that depend on a failed one are skipped.
+func (s *ReadinessService) checkProject(ctx context.Context, project *Project) []models.ComponentCheck {
+	notionService := project.NotionService
+
+	keyCheck := models.ComponentCheck{Name: models.ComponentNotionAPIKey, Project: project.Name, Status: models.CheckPass}
+	apiCheck := models.ComponentCheck{Name: models.ComponentNotionAPI, Project: project.Name}
+	databaseCheck := models.ComponentCheck{Name: models.ComponentNotionDatabase, Project: project.Name}
+
+	if !notionService.HasAPIKey() {
+		keyCheck.Status = models.CheckFail
+		keyCheck.Message = "no Notion API key configured"
+		apiCheck.Status = models.CheckSkip
+		apiCheck.Message = "no Notion API key configured"
+		databaseCheck.Status = models.CheckSkip
+		databaseCheck.Message = "no Notion API key configured"
+		return []models.ComponentCheck{keyCheck, apiCheck, databaseCheck}
+	}
+
+	apiCheck.LatencyMS, apiCheck.Status, apiCheck.Message = timeCheck(ctx, func(ctx context.Context) (string, error) {
+		user, err := notionService.WithContext(ctx).GetCurrentUser()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("authenticated as %s", userLabel(user)), nil
+	})
+
+	switch {
+	case apiCheck.Status != models.CheckPass:
+		databaseCheck.Status = models.CheckSkip
+		databaseCheck.Message = "Notion API check failed"
+	case project.DatabaseID == "":
+		databaseCheck.Status = models.CheckSkip
+		databaseCheck.Message = "no database configured; the workspace is searched"
+	default:
+		databaseCheck.LatencyMS, databaseCheck.Status, databaseCheck.Message = timeCheck(ctx, func(ctx context.Context) (string, error) {
+			if err := notionService.WithContext(ctx).CheckDatabase(); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("database %s is accessible", project.DatabaseID), nil
+		})
+	}
+
+	return []models.ComponentCheck{keyCheck, apiCheck, databaseCheck}
+}
+
+// checkSync fails when the last sync of a project is older than the maximum
+// age. A project that has not finished its first sync only warns, since the
+// first sync of a large database may take a while.
+func (s *ReadinessService) checkSync(project string, lastSynced map[string]time.Time) models.ComponentCheck {
+	check := models.ComponentCheck{Name: models.ComponentSync, Project: project}
+
+	synced, ok := lastSynced[project]
+	if !ok {
+		check.Status = models.CheckWarn
+		check.Message = "no sync finished yet"
+		return check
+	}
+
+	age := time.Since(synced).Round(time.Second)
+	if age > s.maxSyncAge {
+		check.Status = models.CheckFail
+		check.Message = fmt.Sprintf("last sync finished %s ago, more than %s", age, s.maxSyncAge)
+		return check
+	}
+
+	check.Status = models.CheckPass
+	check.Message = fmt.Sprintf("last sync finished %s ago", age)
+	return check
+}
+
+// Helper functions
+
+// timeCheck runs check with a timeout and returns its latency, status and message
+func timeCheck(ctx context.Context, check func(context.Context) (string, error)) (int64, string, string) {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	message, err := check(ctx)
+	latency := time.Since(start).Milliseconds()
+	if err != nil {
+		return latency, models.CheckFail, err.Error()
+	}
+	return latency, models.CheckPass, message
+}
+
+func userLabel(user *models.NotionUser) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	return user.ID
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"demo-notion-api/models"
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+type ResultsService struct {
+	notionService *NotionService
+}
+
+func NewResultsService(notionService *NotionService) *ResultsService {
+	return &ResultsService{
+		notionService: notionService,
+	}
+}
+
+// WithContext returns a copy of the service whose Notion calls carry ctx
+func (s *ResultsService) WithContext(ctx context.Context) *ResultsService {
+	return &ResultsService{notionService: s.notionService.WithContext(ctx)}
+}
+
+// junitResult is the outcome of one JUnit testcase together with its run date
+type junitResult struct {
+	Name    string
+	Outcome string
+	Date    string
+}
+
+// ParseJUnit reads a JUnit XML report whose root is either testsuites or testsuite
+func ParseJUnit(data []byte) (*models.JUnitTestSuites, error) {
+	var report models.JUnitTestSuites
+	if err := xml.Unmarshal(data, &report); err == nil {
+		return &report, nil
+	}
+
+	var suite models.JUnitTestSuite
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&suite); err != nil {
+		return nil, fmt.Errorf("failed to parse JUnit report: %w", err)
+	}
+	return &models.JUnitTestSuites{Suites: []models.JUnitTestSuite{suite}}, nil
+}
+
+// IngestJUnit matches JUnit testcases to test cases by the schema key pattern and,
+// unless dryRun is set, writes their Status and Test Date to Notion.
+// Several testcases with the same key fail the test case when any of them
+// failed, and skip it only when all of them were skipped.
+func (s *ResultsService) IngestJUnit(report *models.JUnitTestSuites, dryRun bool) (*models.JUnitIngestResult, error) {
+	testCases, err := s.notionService.SearchTestCases()
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]models.TestCaseResponse)
+	for _, tc := range testCases {
+		byKey[tc.TestCaseKey] = tc
+	}
+
+	result := &models.JUnitIngestResult{
+		DryRun:    dryRun,
+		Matched:   []models.JUnitMatch{},
+		Updated:   []string{},
+		Unchanged: []string{},
+		Unknown:   []models.JUnitUnknown{},
+	}
+
+	schema := s.notionService.Schema()
+	var order []string
+	results := make(map[string][]junitResult)
+
+	for _, suite := range report.Suites {
+		date := suiteDate(suite.Timestamp)
+		for _, testCase := range suite.TestCases {
+			key := schema.ExtractKey(testCase.Name)
+			if key == "" {
+				result.Unknown = append(result.Unknown, models.JUnitUnknown{
+					Name:   testCase.Name,
+					Reason: "name does not contain a test case key",
+				})
+				continue
+			}
+			if _, exists := byKey[key]; !exists {
+				result.Unknown = append(result.Unknown, models.JUnitUnknown{
+					Name:        testCase.Name,
+					TestCaseKey: key,
+					Reason:      "no test case with this key",
+				})
+				continue
+			}
+
+			if _, seen := results[key]; !seen {
+				order = append(order, key)
+			}
+			results[key] = append(results[key], junitResult{
+				Name:    testCase.Name,
+				Outcome: junitOutcome(testCase),
+				Date:    date,
+			})
+		}
+	}
+
+	for _, key := range order {
+		tc := byKey[key]
+		outcome, date := aggregateJUnitResults(results[key])
+
+		match := models.JUnitMatch{
+			TestCaseKey:    key,
+			PageID:         tc.PageID,
+			Outcome:        outcome,
+			PreviousStatus: tc.Status,
+			Status:         schema.StatusName(outcome),
+			TestDate:       date,
+		}
+		for _, r := range results[key] {
+			match.JUnitTestCases = append(match.JUnitTestCases, r.Name)
+		}
+		result.Matched = append(result.Matched, match)
+
+		if match.Status == tc.Status && match.TestDate == tc.TestDate {
+			result.Unchanged = append(result.Unchanged, key)
+			continue
+		}
+
+		if !dryRun {
+			update := models.TestCaseUpdate{Status: &match.Status, TestDate: &match.TestDate}
+			if err := s.notionService.UpdateTestCase(tc.PageID, update); err != nil {
+				result.Errors = append(result.Errors, models.ImportError{TestCaseKey: key, Message: err.Error()})
+				continue
+			}
+		}
+		result.Updated = append(result.Updated, key)
+	}
+
+	return result, nil
+}
+
+// Helper functions
+
+func junitOutcome(testCase models.JUnitTestCase) string {
+	switch {
+	case testCase.Failure != nil || testCase.Error != nil:
+		return models.OutcomeFailed
+	case testCase.Skipped != nil:
+		return models.OutcomeSkipped
+	default:
+		return models.OutcomePassed
+	}
+}
+
+func aggregateJUnitResults(results []junitResult) (string, string) {
+	outcome := models.OutcomeSkipped
+	date := ""
+	for _, r := range results {
+		switch {
+		case r.Outcome == models.OutcomeFailed:
+			outcome = models.OutcomeFailed
+		case r.Outcome == models.OutcomePassed && outcome == models.OutcomeSkipped:
+			outcome = models.OutcomePassed
+		}
+		if r.Date > date {
+			date = r.Date
+		}
+	}
+	return outcome, date
+}
+
+// suiteDate returns the date of a testsuite timestamp, or today (UTC) when it is missing or invalid
+func suiteDate(timestamp string) string {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return t.Format("2006-01-02")
+		}
+	}
+	return time.Now().UTC().Format("2006-01-02")
+}
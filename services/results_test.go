@@ -0,0 +1,132 @@
+package services
+
+import (
+	"demo-notion-api/models"
+	"testing"
+	"time"
+)
+
+func TestParseJUnit(t *testing.T) {
+	tests := []struct {
+		name       string
+		xml        string
+		wantSuites int
+		wantCases  []string
+		wantErr    bool
+	}{
+		{
+			name: "testsuites root",
+			xml: `<?xml version="1.0"?>
+<testsuites>
+  <testsuite name="a" timestamp="2026-01-02T03:04:05"><testcase name="TC_01001 Login"/></testsuite>
+  <testsuite name="b"><testcase name="TC_01002 Logout"><failure message="boom"/></testcase></testsuite>
+</testsuites>`,
+			wantSuites: 2,
+			wantCases:  []string{"TC_01001 Login", "TC_01002 Logout"},
+		},
+		{
+			name:       "testsuite root",
+			xml:        `<testsuite name="a"><testcase name="TC_01001 Login"><skipped/></testcase></testsuite>`,
+			wantSuites: 1,
+			wantCases:  []string{"TC_01001 Login"},
+		},
+		{name: "not xml", xml: `{"tests": 1}`, wantErr: true},
+		{name: "truncated", xml: `<testsuite name="a"><testcase`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := ParseJUnit([]byte(tt.xml))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJUnit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(report.Suites) != tt.wantSuites {
+				t.Fatalf("suites = %d, want %d", len(report.Suites), tt.wantSuites)
+			}
+			var names []string
+			for _, suite := range report.Suites {
+				for _, tc := range suite.TestCases {
+					names = append(names, tc.Name)
+				}
+			}
+			if len(names) != len(tt.wantCases) {
+				t.Fatalf("testcases = %q, want %q", names, tt.wantCases)
+			}
+			for i := range names {
+				if names[i] != tt.wantCases[i] {
+					t.Errorf("testcase %d = %q, want %q", i, names[i], tt.wantCases[i])
+				}
+			}
+		})
+	}
+}
+
+func TestJUnitOutcome(t *testing.T) {
+	message := &models.JUnitMessage{}
+	tests := []struct {
+		name     string
+		testCase models.JUnitTestCase
+		want     string
+	}{
+		{name: "passed", testCase: models.JUnitTestCase{}, want: models.OutcomePassed},
+		{name: "failure", testCase: models.JUnitTestCase{Failure: message}, want: models.OutcomeFailed},
+		{name: "error", testCase: models.JUnitTestCase{Error: message}, want: models.OutcomeFailed},
+		{name: "skipped", testCase: models.JUnitTestCase{Skipped: message}, want: models.OutcomeSkipped},
+	}
+
+	for _, tt := range tests {
+		if got := junitOutcome(tt.testCase); got != tt.want {
+			t.Errorf("%s: junitOutcome() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAggregateJUnitResults(t *testing.T) {
+	passed := func(date string) junitResult { return junitResult{Outcome: models.OutcomePassed, Date: date} }
+	failed := func(date string) junitResult { return junitResult{Outcome: models.OutcomeFailed, Date: date} }
+	skipped := func(date string) junitResult { return junitResult{Outcome: models.OutcomeSkipped, Date: date} }
+
+	tests := []struct {
+		name        string
+		results     []junitResult
+		wantOutcome string
+		wantDate    string
+	}{
+		{name: "single pass", results: []junitResult{passed("2026-01-02")}, wantOutcome: models.OutcomePassed, wantDate: "2026-01-02"},
+		{name: "any failure fails", results: []junitResult{passed("2026-01-02"), failed("2026-01-01"), passed("2026-01-03")}, wantOutcome: models.OutcomeFailed, wantDate: "2026-01-03"},
+		{name: "failure is not undone by a later pass", results: []junitResult{failed("2026-01-02"), passed("2026-01-02")}, wantOutcome: models.OutcomeFailed, wantDate: "2026-01-02"},
+		{name: "pass wins over skip", results: []junitResult{skipped("2026-01-02"), passed("2026-01-01")}, wantOutcome: models.OutcomePassed, wantDate: "2026-01-02"},
+		{name: "all skipped", results: []junitResult{skipped("2026-01-01"), skipped("2026-01-02")}, wantOutcome: models.OutcomeSkipped, wantDate: "2026-01-02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome, date := aggregateJUnitResults(tt.results)
+			if outcome != tt.wantOutcome || date != tt.wantDate {
+				t.Errorf("aggregateJUnitResults() = %q, %q, want %q, %q", outcome, date, tt.wantOutcome, tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestSuiteDate(t *testing.T) {
+	today := time.Now().UTC().Format("2006-01-02")
+	tests := []struct {
+		timestamp string
+		want      string
+	}{
+		{timestamp: "2026-01-02T03:04:05", want: "2026-01-02"},
+		{timestamp: "2026-01-02T23:30:00+07:00", want: "2026-01-02"},
+		{timestamp: "", want: today},
+		{timestamp: "yesterday", want: today},
+	}
+
+	for _, tt := range tests {
+		if got := suiteDate(tt.timestamp); got != tt.want {
+			t.Errorf("suiteDate(%q) = %q, want %q", tt.timestamp, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package services
+
+import (
+	"demo-notion-api/models"
+	"html"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Search tuning
+const (
+	trigramLength  = 3
+	snippetContext = 40
+	titleHitScore  = 5
+	otherHitScore  = 1
+)
+
+// searchDocument is one indexed piece of text of a test case
+type searchDocument struct {
+	testCase int
+	hit      models.SearchHit
+	text     string
+	folded   string
+}
+
+// searchIndex is an inverted index from character trigrams to documents.
+// Trigrams find text in any language, including scripts written without spaces.
+type searchIndex struct {
+	testCases []models.DetailedTestCaseResponse
+	documents []searchDocument
+	postings  map[string][]int
+}
+
+// SearchService answers full-text queries from the synced test case history
+type SearchService struct {
+	history *HistoryService
+	mu      sync.RWMutex
+	index   *searchIndex
+}
+
+// NewSearchService builds the index from the versions already on disk
+func NewSearchService(history *HistoryService) *SearchService {
+	s := &SearchService{history: history}
+	s.Rebuild()
+	return s
+}
+
+// Rebuild replaces the index with one built from the latest version of every test case
+func (s *SearchService) Rebuild() {
+	index := &searchIndex{postings: make(map[string][]int)}
+	for _, version := range s.history.LatestVersions() {
+		index.add(version)
+	}
+
+	s.mu.Lock()
+	s.index = index
+	s.mu.Unlock()
+}
+
+// Search returns the test cases containing every word of query, case-insensitively.
+// With project set only that project is searched. At most limit results are
+// returned; Total counts all matching test cases.
+func (s *SearchService) Search(query, project string, limit int) *models.SearchResponse {
+	s.mu.RLock()
+	index := s.index
+	s.mu.RUnlock()
+
+	response := &models.SearchResponse{Query: query, Results: []models.SearchResult{}}
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return response
+	}
+
+	// Documents containing each word, grouped by test case
+	matches := make(map[int]map[int]bool)
+	for i, word := range words {
+		found := make(map[int]map[int]bool)
+		for _, doc := range index.candidates(word) {
+			document := index.documents[doc]
+			if project != "" && index.testCases[document.testCase].Project != project {
+				continue
+			}
+			if !strings.Contains(document.folded, word) {
+				continue
+			}
+			if found[document.testCase] == nil {
+				found[document.testCase] = make(map[int]bool)
+			}
+			found[document.testCase][doc] = true
+		}
+
+		if i == 0 {
+			matches = found
+			continue
+		}
+		for testCase, docs := range matches {
+			if found[testCase] == nil {
+				delete(matches, testCase)
+				continue
+			}
+			for doc := range found[testCase] {
+				docs[doc] = true
+			}
+		}
+	}
+
+	for testCase, docs := range matches {
+		tc := index.testCases[testCase]
+		result := models.SearchResult{
+			Project:     tc.Project,
+			TestCaseKey: tc.TestCaseKey,
+			PageID:      tc.PageID,
+			Title:       tc.Title,
+			URL:         tc.URL,
+		}
+
+		ids := make([]int, 0, len(docs))
+		for doc := range docs {
+			ids = append(ids, doc)
+		}
+		sort.Ints(ids)
+
+		for _, doc := range ids {
+			document := index.documents[doc]
+			hit := document.hit
+			hit.Snippet = highlight(document.text, words)
+			result.Hits = append(result.Hits, hit)
+
+			if hit.Field == models.SearchFieldTitle {
+				result.Score += titleHitScore
+			} else {
+				result.Score += otherHitScore
+			}
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	sort.Slice(response.Results, func(i, j int) bool {
+		a, b := response.Results[i], response.Results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return compareKeys(a.TestCaseKey, b.TestCaseKey) < 0
+	})
+
+	response.Total = len(response.Results)
+	if limit > 0 && len(response.Results) > limit {
+		response.Results = response.Results[:limit]
+	}
+	return response
+}
+
+// Helper methods
+
+func (idx *searchIndex) add(version models.TestCaseVersion) {
+	tc := version.TestCase
+	tc.Tables = nil
+	idx.testCases = append(idx.testCases, tc)
+	testCase := len(idx.testCases) - 1
+
+	idx.addDocument(testCase, models.SearchHit{Field: models.SearchFieldTitle}, tc.Title)
+
+	for _, block := range textBlocks(version.Blocks) {
+		idx.addDocument(testCase, models.SearchHit{
+			Field:     models.SearchFieldBlock,
+			BlockID:   block.BlockID,
+			BlockType: block.Type,
+		}, block.Content)
+	}
+
+	for _, table := range version.TestCase.Tables {
+		stepRows := make(map[string]int)
+		for _, step := range table.Steps {
+			stepRows[step.BlockID] = step.Number
+		}
+
+		var header []string
+		for r, row := range table.Rows {
+			if r == 0 && table.HasColumnHeader {
+				header = row.Cells
+			}
+			for c, cell := range row.Cells {
+				hit := models.SearchHit{
+					Field:      models.SearchFieldTableCell,
+					BlockID:    table.BlockID,
+					BlockType:  "table",
+					RowBlockID: row.BlockID,
+					StepNumber: stepRows[row.BlockID],
+				}
+				if c < len(header) && r > 0 {
+					hit.Column = header[c]
+				}
+				idx.addDocument(testCase, hit, cell)
+			}
+		}
+	}
+}
+
+func (idx *searchIndex) addDocument(testCase int, hit models.SearchHit, text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+
+	folded := strings.ToLower(text)
+	idx.documents = append(idx.documents, searchDocument{
+		testCase: testCase,
+		hit:      hit,
+		text:     text,
+		folded:   folded,
+	})
+	doc := len(idx.documents) - 1
+
+	seen := make(map[string]bool)
+	for _, gram := range trigrams(folded) {
+		if !seen[gram] {
+			seen[gram] = true
+			idx.postings[gram] = append(idx.postings[gram], doc)
+		}
+	}
+}
+
+// candidates returns the documents holding every trigram of word. Words shorter
+// than a trigram cannot be looked up and match against every document.
+func (idx *searchIndex) candidates(word string) []int {
+	grams := trigrams(word)
+	if len(grams) == 0 {
+		all := make([]int, len(idx.documents))
+		for i := range all {
+			all[i] = i
+		}
+		return all
+	}
+
+	result := idx.postings[grams[0]]
+	for _, gram := range grams[1:] {
+		result = intersect(result, idx.postings[gram])
+		if len(result) == 0 {
+			break
+		}
+	}
+	return result
+}
+
+// Helper functions
+
+func trigrams(text string) []string {
+	runes := []rune(text)
+	if len(runes) < trigramLength {
+		return nil
+	}
+
+	grams := make([]string, 0, len(runes)-trigramLength+1)
+	for i := 0; i+trigramLength <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+trigramLength]))
+	}
+	return grams
+}
+
+// intersect merges two ascending posting lists
+func intersect(a, b []int) []int {
+	var result []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// highlight cuts a snippet around the first match of any word and wraps every
+// match inside it in <mark> tags. The surrounding text is HTML-escaped.
+func highlight(text string, words []string) string {
+	// Fold rune by rune as the index does, keeping the byte offset of every
+	// rune, since folding may change the byte length of the text
+	var folded []rune
+	var offsets []int
+	for offset, r := range text {
+		folded = append(folded, unicode.ToLower(r))
+		offsets = append(offsets, offset)
+	}
+	offsets = append(offsets, len(text))
+
+	type span struct{ start, end int }
+	var spans []span
+	for _, word := range words {
+		target := []rune(word)
+		if len(target) == 0 {
+			continue
+		}
+		for i := 0; i+len(target) <= len(folded); {
+			if !slices.Equal(folded[i:i+len(target)], target) {
+				i++
+				continue
+			}
+			spans = append(spans, span{offsets[i], offsets[i+len(target)]})
+			i += len(target)
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	from, to := 0, len(text)
+	if len(spans) > 0 {
+		from = runeBoundary(text, spans[0].start-snippetContext)
+		to = runeBoundary(text, spans[len(spans)-1].end+snippetContext)
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	position := from
+	for _, sp := range spans {
+		if sp.start < position || sp.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[position:sp.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[sp.start:sp.end]))
+		b.WriteString("</mark>")
+		position = sp.end
+	}
+	b.WriteString(html.EscapeString(text[position:to]))
+	if to < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// runeBoundary clamps i to text and moves it back to the start of a rune
+func runeBoundary(text string, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	if i >= len(text) {
+		return len(text)
+	}
+	for i > 0 && !utf8.RuneStart(text[i]) {
+		i--
+	}
+	return i
+}
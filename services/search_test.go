@@ -0,0 +1,203 @@
+package services
+
+import (
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestSearch indexes a web and a mobile test case
+func newTestSearch(t *testing.T) *SearchService {
+	t.Helper()
+
+	history, err := NewHistoryService(&config.Config{DataDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	versions := []struct {
+		project string
+		version models.TestCaseVersion
+	}{
+		{"web", models.TestCaseVersion{
+			LastEdited: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			TestCase: models.DetailedTestCaseResponse{
+				Project: "web", TestCaseKey: "01001", PageID: "p1", Title: "TC_01001 Login with password",
+				Tables: []models.TableWithData{{
+					BlockID: "table", HasColumnHeader: true,
+					Rows: []models.TableRow{
+						{BlockID: "header", Cells: []string{"Step", "Action", "Expected Result"}},
+						{BlockID: "row1", Cells: []string{"1", "Enter the password", "Dashboard <shown>"}},
+					},
+					Steps: []models.TestStep{{Number: 1, BlockID: "row1", Action: "Enter the password", ExpectedResult: "Dashboard <shown>"}},
+				}},
+			},
+			Blocks: []models.BlockResponse{{BlockID: "intro", Type: "paragraph", Content: "Die GROẞE Anmeldung"}},
+		}},
+		{"mobile", models.TestCaseVersion{
+			LastEdited: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			TestCase: models.DetailedTestCaseResponse{
+				Project: "mobile", TestCaseKey: "02001", PageID: "p2", Title: "TC_02001 ログイン画面",
+			},
+			Blocks: []models.BlockResponse{{BlockID: "note", Type: "paragraph", Content: "Password reset from the login screen"}},
+		}},
+	}
+	for _, v := range versions {
+		if _, err := history.Record(v.project, v.version); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewSearchService(history)
+}
+
+func TestSearch(t *testing.T) {
+	search := newTestSearch(t)
+
+	type hit struct {
+		key     string
+		field   string
+		snippet string
+	}
+	tests := []struct {
+		name      string
+		query     string
+		project   string
+		limit     int
+		wantTotal int
+		wantHits  []hit
+	}{
+		{
+			name: "title hits rank first", query: "PASSWORD", wantTotal: 2,
+			wantHits: []hit{
+				{"01001", models.SearchFieldTitle, "TC_01001 Login with <mark>password</mark>"},
+				{"01001", models.SearchFieldTableCell, "Enter the <mark>password</mark>"},
+				{"02001", models.SearchFieldBlock, "<mark>Password</mark> reset from the login screen"},
+			},
+		},
+		{
+			name: "every word must match", query: "login reset", wantTotal: 1,
+			wantHits: []hit{
+				{"02001", models.SearchFieldBlock, "Password <mark>reset</mark> from the <mark>login</mark> screen"},
+			},
+		},
+		{
+			name: "project filter", query: "password", project: "mobile", wantTotal: 1,
+			wantHits: []hit{{"02001", models.SearchFieldBlock, "<mark>Password</mark> reset from the login screen"}},
+		},
+		{
+			name: "limit keeps the total", query: "password", limit: 1, wantTotal: 2,
+			wantHits: []hit{
+				{"01001", models.SearchFieldTitle, "TC_01001 Login with <mark>password</mark>"},
+				{"01001", models.SearchFieldTableCell, "Enter the <mark>password</mark>"},
+			},
+		},
+		{
+			name: "scripts without spaces", query: "ログイン", wantTotal: 1,
+			wantHits: []hit{{"02001", models.SearchFieldTitle, "TC_02001 <mark>ログイン</mark>画面"}},
+		},
+		{
+			name: "folding that changes the byte length", query: "große", wantTotal: 1,
+			wantHits: []hit{{"01001", models.SearchFieldBlock, "Die <mark>GROẞE</mark> Anmeldung"}},
+		},
+		{
+			name: "words shorter than a trigram", query: "tc 02", wantTotal: 1,
+			wantHits: []hit{{"02001", models.SearchFieldTitle, "<mark>TC</mark>_<mark>02</mark>001 ログイン画面"}},
+		},
+		{
+			name: "snippets are escaped", query: "shown", wantTotal: 1,
+			wantHits: []hit{{"01001", models.SearchFieldTableCell, "Dashboard &lt;<mark>shown</mark>&gt;"}},
+		},
+		{name: "no match", query: "logout", wantTotal: 0},
+		{name: "empty query", query: "  ", wantTotal: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := search.Search(tt.query, tt.project, tt.limit)
+			if response.Total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", response.Total, tt.wantTotal)
+			}
+
+			var hits []hit
+			for _, result := range response.Results {
+				for _, h := range result.Hits {
+					hits = append(hits, hit{result.TestCaseKey, h.Field, h.Snippet})
+				}
+			}
+			if !reflect.DeepEqual(hits, tt.wantHits) {
+				t.Errorf("hits = %q, want %q", hits, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestSearchHitLocations(t *testing.T) {
+	response := newTestSearch(t).Search("enter", "", 0)
+	if len(response.Results) != 1 || len(response.Results[0].Hits) != 1 {
+		t.Fatalf("results = %+v, want one hit", response.Results)
+	}
+
+	got := response.Results[0].Hits[0]
+	got.Snippet = ""
+	want := models.SearchHit{
+		Field:      models.SearchFieldTableCell,
+		BlockID:    "table",
+		BlockType:  "table",
+		RowBlockID: "row1",
+		StepNumber: 1,
+		Column:     "Action",
+	}
+	if got != want {
+		t.Errorf("hit = %+v, want %+v", got, want)
+	}
+}
+
+func TestTrigrams(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "ab", want: nil},
+		{text: "abc", want: []string{"abc"}},
+		{text: "login", want: []string{"log", "ogi", "gin"}},
+		{text: "ログイン", want: []string{"ログイ", "グイン"}},
+	}
+
+	for _, tt := range tests {
+		if got := trigrams(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("trigrams(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	long := "Open the start page, then scroll down past the news and the footer links to find the login button at the very bottom of the page, below the imprint"
+
+	tests := []struct {
+		name  string
+		text  string
+		words []string
+		want  string
+	}{
+		{name: "case-insensitive", text: "Login and LOGIN", words: []string{"login"}, want: "<mark>Login</mark> and <mark>LOGIN</mark>"},
+		{name: "several words", text: "Log in, then log out", words: []string{"out", "log"}, want: "<mark>Log</mark> in, then <mark>log</mark> <mark>out</mark>"},
+		{name: "overlapping matches keep the first", text: "aaaa", words: []string{"aaa", "aa"}, want: "<mark>aaa</mark>a"},
+		{name: "byte length changes before the match", text: "İstanbul login", words: []string{"login"}, want: "İstanbul <mark>login</mark>"},
+		{name: "folded match with a longer byte length", text: "İSTANBUL", words: []string{"istanbul"}, want: "<mark>İSTANBUL</mark>"},
+		{name: "no match", text: "a < b", words: []string{"zzz"}, want: "a &lt; b"},
+		{
+			name: "long text is cut around the matches", text: long, words: []string{"login"},
+			want: "…e news and the footer links to find the <mark>login</mark> button at the very bottom of the page, …",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlight(tt.text, tt.words); got != tt.want {
+				t.Errorf("highlight() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"demo-notion-api/config"
+	"demo-notion-api/logging"
+	"demo-notion-api/models"
+	"demo-notion-api/store"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// snapshotInterval is how often the scheduler refreshes the day's snapshots
+const snapshotInterval = time.Hour
+
+// SnapshotService keeps a daily snapshot of every test case's status per project
+type SnapshotService struct {
+	projects  *ProjectRegistry
+	file      *store.JSONFile
+	mu        sync.RWMutex
+	snapshots []models.StatusSnapshot
+}
+
+// NewSnapshotService loads previously taken snapshots from the data directory
+func NewSnapshotService(cfg *config.Config, projects *ProjectRegistry) (*SnapshotService, error) {
+	s := &SnapshotService{
+		projects: projects,
+		file:     store.NewJSONFile(filepath.Join(cfg.DataDir, "snapshots.json")),
+	}
+	if err := s.file.Load(&s.snapshots); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Start takes today's snapshots now and refreshes them hourly in the background
+// until ctx ends, so the snapshot of a day holds its statuses as of the last
+// hour of that day (UTC) and a restart neither skips nor duplicates a day
+func (s *SnapshotService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(snapshotInterval)
+		defer ticker.Stop()
+
+		for {
+			runCtx := logging.WithRequestID(ctx, "snapshot-"+logging.NewRequestID())
+			if err := s.TakeSnapshots(runCtx, today()); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(runCtx, "failed to take status snapshots", slog.Any("error", err))
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// TakeSnapshots records the statuses of every project for date, replacing the
+// snapshots already taken for date. A project that cannot be read keeps its
+// earlier snapshot and is reported after the others are saved.
+func (s *SnapshotService) TakeSnapshots(ctx context.Context, date string) error {
+	var taken []models.StatusSnapshot
+	var failed error
+
+	for _, info := range s.projects.List() {
+		project, _ := s.projects.Get(info.Name)
+		testCases, err := project.NotionService.WithContext(ctx).SearchTestCases()
+		if err != nil {
+			failed = fmt.Errorf("project %s: %w", info.Name, err)
+			continue
+		}
+
+		snapshot := models.StatusSnapshot{
+			Date:     date,
+			Project:  info.Name,
+			TakenAt:  time.Now().UTC().Format(time.RFC3339),
+			Statuses: make(map[string]string, len(testCases)),
+		}
+		for _, tc := range testCases {
+			snapshot.Statuses[tc.TestCaseKey] = tc.Status
+		}
+		taken = append(taken, snapshot)
+	}
+
+	if err := s.save(taken); err != nil {
+		return err
+	}
+	return failed
+}
+
+// Trend returns the progress of a project on every snapshot date from from to to, inclusive
+func (s *SnapshotService) Trend(projectName, from, to string) (*models.TrendResponse, error) {
+	project, err := s.projects.Get(projectName)
+	if err != nil {
+		return nil, err
+	}
+	schema := project.NotionService.Schema()
+
+	trend := &models.TrendResponse{
+		Project: projectName,
+		From:    from,
+		To:      to,
+		Points:  []models.TrendPoint{},
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, snapshot := range s.snapshots {
+		if snapshot.Project != projectName || snapshot.Date < from || snapshot.Date > to {
+			continue
+		}
+
+		testCases := make([]models.TestCaseResponse, 0, len(snapshot.Statuses))
+		for key, status := range snapshot.Statuses {
+			testCases = append(testCases, models.TestCaseResponse{TestCaseKey: key, Status: status})
+		}
+		trend.Points = append(trend.Points, models.TrendPoint{
+			Date:            snapshot.Date,
+			ProgressSummary: SummarizeProgress(schema, testCases),
+		})
+	}
+
+	return trend, nil
+}
+
+// Helper methods
+
+// save stores taken, replacing the snapshots of the same project and date
+func (s *SnapshotService) save(taken []models.StatusSnapshot) error {
+	if len(taken) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := make([]models.StatusSnapshot, 0, len(s.snapshots)+len(taken))
+	for _, snapshot := range s.snapshots {
+		replaced := false
+		for _, newer := range taken {
+			if newer.Project == snapshot.Project && newer.Date == snapshot.Date {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			kept = append(kept, snapshot)
+		}
+	}
+	kept = append(kept, taken...)
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].Date < kept[j].Date
+	})
+
+	if err := s.file.Save(kept); err != nil {
+		return err
+	}
+	s.snapshots = kept
+	return nil
+}
+
+// today returns the current UTC date, which is the day a snapshot belongs to
+func today() string {
+	return time.Now().UTC().Format("2006-01-02")
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"demo-notion-api/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTakeSnapshotsRefreshesTheDay(t *testing.T) {
+	status := "Not run"
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			http.Error(w, "unavailable", http.StatusBadGateway)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"results": []any{map[string]any{
+			"object": "page",
+			"id":     "page-01001",
+			"properties": map[string]any{
+				"Test Case Name": map[string]any{"type": "title", "title": []map[string]any{{"plain_text": "TC_01001 Login"}}},
+				"Status":         map[string]any{"type": "status", "status": map[string]any{"name": status}},
+			},
+		}}})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{NotionAPIURL: server.URL, DataDir: t.TempDir()}
+	projects, err := NewProjectRegistry(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshots, err := NewSnapshotService(cfg, projects)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		name    string
+		date    string
+		status  string
+		failing bool
+		want    map[string]string
+	}{
+		{name: "first snapshot of the day", date: "2026-01-05", status: "Not run", want: map[string]string{"2026-01-05": "Not run"}},
+		{name: "later in the day", date: "2026-01-05", status: "Passed", want: map[string]string{"2026-01-05": "Passed"}},
+		{name: "failed refresh keeps the snapshot", date: "2026-01-05", status: "Failed", failing: true, want: map[string]string{"2026-01-05": "Passed"}},
+		{name: "next day", date: "2026-01-06", status: "Failed", want: map[string]string{"2026-01-05": "Passed", "2026-01-06": "Failed"}},
+	}
+
+	for _, step := range steps {
+		status, failing = step.status, step.failing
+		err := snapshots.TakeSnapshots(context.Background(), step.date)
+		if (err != nil) != step.failing {
+			t.Fatalf("%s: TakeSnapshots() error = %v", step.name, err)
+		}
+
+		reloaded, err := NewSnapshotService(cfg, projects)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]string)
+		for _, snapshot := range reloaded.snapshots {
+			if _, ok := got[snapshot.Date]; ok {
+				t.Errorf("%s: %s has several snapshots", step.name, snapshot.Date)
+			}
+			got[snapshot.Date] = snapshot.Statuses["01001"]
+		}
+		if len(got) != len(step.want) {
+			t.Errorf("%s: snapshots = %v, want %v", step.name, got, step.want)
+		}
+		for date, want := range step.want {
+			if got[date] != want {
+				t.Errorf("%s: %s = %q, want %q", step.name, date, got[date], want)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"fmt"
+	"time"
+)
+
+// Test date buckets accepted by ComputeStats
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+)
+
+// Labels for test cases without a value in a breakdown
+const (
+	unscheduledLabel = "unscheduled"
+	untaggedLabel    = "untagged"
+	unassignedLabel  = "unassigned"
+)
+
+// ComputeStats aggregates test case and step outcomes. Test cases are counted
+// once per tag and once per assignee, so those breakdowns may add up to more
+// than the total.
+func ComputeStats(schema *config.Schema, testCases []models.DetailedTestCaseResponse, bucket string) models.StatsResponse {
+	summaries := make([]models.TestCaseResponse, 0, len(testCases))
+	stats := models.StatsResponse{
+		DateBucket: bucket,
+		ByTestDate: make(map[string]int),
+		ByTag:      make(map[string]int),
+		ByAssignee: make(map[string]int),
+	}
+
+	for _, tc := range testCases {
+		summaries = append(summaries, summaryOf(tc))
+
+		stats.ByTestDate[dateBucket(tc.TestDate, bucket)]++
+
+		if len(tc.Tags) == 0 {
+			stats.ByTag[untaggedLabel]++
+		}
+		for _, tag := range tc.Tags {
+			stats.ByTag[tag]++
+		}
+
+		if len(tc.Assignees) == 0 {
+			stats.ByAssignee[unassignedLabel]++
+		}
+		for _, assignee := range tc.Assignees {
+			stats.ByAssignee[assignee]++
+		}
+
+		for _, table := range tc.Tables {
+			for _, step := range table.Steps {
+				stats.Steps.Total++
+				switch schema.ClassifyStatus(step.Status) {
+				case models.OutcomePassed:
+					stats.Steps.Passed++
+				case models.OutcomeFailed:
+					stats.Steps.Failed++
+				case models.OutcomeBlocked:
+					stats.Steps.Blocked++
+				case models.OutcomeSkipped:
+					stats.Steps.Skipped++
+				default:
+					stats.Steps.NotRun++
+				}
+			}
+		}
+	}
+
+	stats.Progress = SummarizeProgress(schema, summaries)
+	return stats
+}
+
+// ValidBucket reports whether bucket is a supported test date bucket
+func ValidBucket(bucket string) bool {
+	return bucket == BucketDay || bucket == BucketWeek || bucket == BucketMonth
+}
+
+// dateBucket returns the bucket label of a test date: 2006-01-02, 2006-W01 or 2006-01
+func dateBucket(testDate, bucket string) string {
+	if testDate == "" {
+		return unscheduledLabel
+	}
+
+	t, err := time.Parse("2006-01-02", testDate[:min(len(testDate), 10)])
+	if err != nil {
+		return testDate
+	}
+
+	switch bucket {
+	case BucketWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case BucketMonth:
+		return t.Format("2006-01")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"reflect"
+	"testing"
+)
+
+func TestDateBucket(t *testing.T) {
+	tests := []struct {
+		testDate string
+		bucket   string
+		want     string
+	}{
+		{testDate: "2026-01-05", bucket: BucketDay, want: "2026-01-05"},
+		{testDate: "2026-01-05T23:30:00.000+07:00", bucket: BucketDay, want: "2026-01-05"},
+
+		// ISO weeks start on Monday and belong to the year of their Thursday
+		{testDate: "2025-12-28", bucket: BucketWeek, want: "2025-W52"},
+		{testDate: "2025-12-29", bucket: BucketWeek, want: "2026-W01"},
+		{testDate: "2026-01-01", bucket: BucketWeek, want: "2026-W01"},
+		{testDate: "2026-01-04", bucket: BucketWeek, want: "2026-W01"},
+		{testDate: "2026-01-05", bucket: BucketWeek, want: "2026-W02"},
+		{testDate: "2027-01-01", bucket: BucketWeek, want: "2026-W53"},
+
+		{testDate: "2026-01-31", bucket: BucketMonth, want: "2026-01"},
+		{testDate: "2026-02-01", bucket: BucketMonth, want: "2026-02"},
+		{testDate: "2026-12-31T23:59:59Z", bucket: BucketMonth, want: "2026-12"},
+
+		{testDate: "", bucket: BucketWeek, want: unscheduledLabel},
+		{testDate: "next sprint", bucket: BucketMonth, want: "next sprint"},
+	}
+
+	for _, tt := range tests {
+		if got := dateBucket(tt.testDate, tt.bucket); got != tt.want {
+			t.Errorf("dateBucket(%q, %s) = %q, want %q", tt.testDate, tt.bucket, got, tt.want)
+		}
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	steps := func(statuses ...string) []models.TableWithData {
+		var table models.TableWithData
+		for i, status := range statuses {
+			table.Steps = append(table.Steps, models.TestStep{Number: i + 1, Status: status})
+		}
+		return []models.TableWithData{table}
+	}
+
+	tests := []struct {
+		name         string
+		testCases    []models.DetailedTestCaseResponse
+		bucket       string
+		wantProgress models.ProgressSummary
+		wantSteps    models.StepStats
+		wantDates    map[string]int
+		wantTags     map[string]int
+		wantAssignee map[string]int
+	}{
+		{
+			name:         "no test cases",
+			bucket:       BucketDay,
+			wantProgress: models.ProgressSummary{ByStatus: map[string]int{}},
+			wantDates:    map[string]int{},
+			wantTags:     map[string]int{},
+			wantAssignee: map[string]int{},
+		},
+		{
+			name: "nothing executed has no pass rate",
+			testCases: []models.DetailedTestCaseResponse{
+				{Status: "Not started"},
+				{Status: "In progress", TestDate: "2026-01-05", Tags: []string{"smoke"}, Assignees: []string{"ann"}},
+			},
+			bucket: BucketWeek,
+			wantProgress: models.ProgressSummary{
+				Total: 2, Remaining: 2, InProgress: 1, NotRun: 1,
+				ByStatus: map[string]int{"Not started": 1, "In progress": 1},
+			},
+			wantDates:    map[string]int{unscheduledLabel: 1, "2026-W02": 1},
+			wantTags:     map[string]int{untaggedLabel: 1, "smoke": 1},
+			wantAssignee: map[string]int{unassignedLabel: 1, "ann": 1},
+		},
+		{
+			name: "only skipped and blocked executions have no pass rate",
+			testCases: []models.DetailedTestCaseResponse{
+				{Status: "Skipped", TestDate: "2026-01-31"},
+				{Status: "Blocked", TestDate: "2026-02-01"},
+			},
+			bucket: BucketMonth,
+			wantProgress: models.ProgressSummary{
+				Total: 2, Executed: 2, Blocked: 1, Skipped: 1,
+				ByStatus: map[string]int{"Skipped": 1, "Blocked": 1},
+			},
+			wantDates:    map[string]int{"2026-01": 1, "2026-02": 1},
+			wantTags:     map[string]int{untaggedLabel: 2},
+			wantAssignee: map[string]int{unassignedLabel: 2},
+		},
+		{
+			name: "pass rate over passed and failed",
+			testCases: []models.DetailedTestCaseResponse{
+				{Status: "Passed", TestDate: "2026-01-05", Tags: []string{"smoke", "auth"}, Assignees: []string{"ann", "bob"}, Tables: steps("Passed", "Pass", "")},
+				{Status: "Passed", TestDate: "2026-01-05", Tags: []string{"smoke"}, Assignees: []string{"ann"}, Tables: steps("Done")},
+				{Status: "Failed", TestDate: "2026-01-06", Tags: []string{"auth"}, Assignees: []string{"bob"}, Tables: steps("Passed", "Failed", "Blocked", "N/A")},
+				{Status: "Skipped", TestDate: "2026-01-06"},
+			},
+			bucket: BucketDay,
+			wantProgress: models.ProgressSummary{
+				Total: 4, Executed: 4, Passed: 2, Failed: 1, Skipped: 1, PassRate: 2.0 / 3.0,
+				ByStatus: map[string]int{"Passed": 2, "Failed": 1, "Skipped": 1},
+			},
+			wantSteps:    models.StepStats{Total: 8, Passed: 4, Failed: 1, Blocked: 1, Skipped: 1, NotRun: 1},
+			wantDates:    map[string]int{"2026-01-05": 2, "2026-01-06": 2},
+			wantTags:     map[string]int{"smoke": 2, "auth": 2, untaggedLabel: 1},
+			wantAssignee: map[string]int{"ann": 2, "bob": 2, unassignedLabel: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := ComputeStats(config.DefaultSchema(), tt.testCases, tt.bucket)
+			if stats.DateBucket != tt.bucket {
+				t.Errorf("date bucket = %q, want %q", stats.DateBucket, tt.bucket)
+			}
+			if !reflect.DeepEqual(stats.Progress, tt.wantProgress) {
+				t.Errorf("progress = %+v, want %+v", stats.Progress, tt.wantProgress)
+			}
+			if stats.Steps != tt.wantSteps {
+				t.Errorf("steps = %+v, want %+v", stats.Steps, tt.wantSteps)
+			}
+			if !reflect.DeepEqual(stats.ByTestDate, tt.wantDates) {
+				t.Errorf("by test date = %v, want %v", stats.ByTestDate, tt.wantDates)
+			}
+			if !reflect.DeepEqual(stats.ByTag, tt.wantTags) {
+				t.Errorf("by tag = %v, want %v", stats.ByTag, tt.wantTags)
+			}
+			if !reflect.DeepEqual(stats.ByAssignee, tt.wantAssignee) {
+				t.Errorf("by assignee = %v, want %v", stats.ByAssignee, tt.wantAssignee)
+			}
+		})
+	}
+}
+
+func TestValidBucket(t *testing.T) {
+	for bucket, want := range map[string]bool{BucketDay: true, BucketWeek: true, BucketMonth: true, "year": false, "": false, "Week": false} {
+		if got := ValidBucket(bucket); got != want {
+			t.Errorf("ValidBucket(%q) = %v, want %v", bucket, got, want)
+		}
+	}
+}
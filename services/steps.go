@@ -0,0 +1,231 @@
+package services
+
+import (
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Step fields that table columns can be mapped to
+const (
+	stepFieldNumber         = "number"
+	stepFieldAction         = "action"
+	stepFieldExpectedResult = "expected_result"
+	stepFieldActualResult   = "actual_result"
+	stepFieldStatus         = "status"
+	stepFieldScreenshot     = "screenshot"
+)
+
+// defaultStepColumns lists the column names recognised for each step field
+var defaultStepColumns = map[string][]string{
+	stepFieldNumber:         {"Step", "Step No", "No", "#"},
+	stepFieldAction:         {"Action", "Test Step", "Description"},
+	stepFieldExpectedResult: {"Expected Result", "Expected"},
+	stepFieldActualResult:   {"Actual Result", "Actual"},
+	stepFieldStatus:         {"Status", "Result"},
+	stepFieldScreenshot:     {"Screenshot", "Evidence"},
+}
+
+// defaultStepOrder is the column order assumed for tables without a header row
+var defaultStepOrder = []string{
+	stepFieldNumber,
+	stepFieldAction,
+	stepFieldExpectedResult,
+	stepFieldActualResult,
+	stepFieldStatus,
+	stepFieldScreenshot,
+}
+
+// StepColumnLookup maps normalized column names to step fields, using the
+// schema's step columns where configured and the defaults otherwise.
+// Configured names win over default names of other fields.
+func StepColumnLookup(schema *config.Schema) map[string]string {
+	lookup := make(map[string]string)
+	for field, names := range defaultStepColumns {
+		if _, configured := schema.StepColumns[field]; configured {
+			continue
+		}
+		for _, name := range names {
+			lookup[normalizeHeader(name)] = field
+		}
+	}
+	for field, names := range schema.StepColumns {
+		for _, name := range names {
+			lookup[normalizeHeader(name)] = field
+		}
+	}
+	return lookup
+}
+
+// ParseSteps maps the rows of a step table to typed steps.
+// When the table has a column header, columns are matched by name through
+// lookup (see StepColumnLookup) and unknown columns go to TestStep.Extra;
+// otherwise the default column order is assumed.
+// Tables whose header names neither an action nor an expected result column,
+// and tables without a header that are not as wide as the default order, are
+// not step tables and yield no steps and no warnings.
+func ParseSteps(table models.TableWithData, lookup map[string]string) ([]models.TestStep, []models.StepWarning) {
+	if len(table.Rows) == 0 {
+		return nil, nil
+	}
+
+	columns := defaultStepOrder
+	headers := make([]string, len(defaultStepOrder))
+	copy(headers, defaultStepOrder)
+	firstRow := 0
+
+	if table.HasColumnHeader {
+		headers = table.Rows[0].Cells
+		columns = mapStepColumns(headers, lookup)
+		firstRow = 1
+
+		if !containsString(columns, stepFieldAction) && !containsString(columns, stepFieldExpectedResult) {
+			return nil, nil
+		}
+	} else if tableWidth(table) != len(defaultStepOrder) {
+		return nil, nil
+	}
+
+	var steps []models.TestStep
+	var warnings []models.StepWarning
+
+	for i := firstRow; i < len(table.Rows); i++ {
+		cells := table.Rows[i].Cells
+		if isBlankRow(cells) {
+			continue
+		}
+
+		if len(cells) != len(columns) {
+			warnings = append(warnings, models.StepWarning{
+				Row:     i,
+				Message: fmt.Sprintf("row has %d cells, expected %d", len(cells), len(columns)),
+			})
+			continue
+		}
+
+		step := models.TestStep{BlockID: table.Rows[i].BlockID}
+		numberCell := ""
+		for col, value := range cells {
+			value = strings.TrimSpace(value)
+			switch columns[col] {
+			case stepFieldNumber:
+				numberCell = value
+			case stepFieldAction:
+				step.Action = value
+			case stepFieldExpectedResult:
+				step.ExpectedResult = value
+			case stepFieldActualResult:
+				step.ActualResult = value
+			case stepFieldStatus:
+				step.Status = value
+			case stepFieldScreenshot:
+				step.Screenshot = value
+			default:
+				if value == "" {
+					continue
+				}
+				if step.Extra == nil {
+					step.Extra = make(map[string]string)
+				}
+				step.Extra[headers[col]] = value
+			}
+		}
+
+		if step.Action == "" && step.ExpectedResult == "" {
+			warnings = append(warnings, models.StepWarning{
+				Row:     i,
+				Message: "row has neither an action nor an expected result",
+			})
+			continue
+		}
+
+		if numberCell == "" {
+			step.Number = len(steps) + 1
+		} else {
+			number, err := strconv.Atoi(strings.TrimSuffix(numberCell, "."))
+			if err != nil {
+				warnings = append(warnings, models.StepWarning{
+					Row:     i,
+					Message: fmt.Sprintf("step number %q is not a number", numberCell),
+				})
+				continue
+			}
+			step.Number = number
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps, warnings
+}
+
+// mapStepColumns returns the step field for each header cell, or "" for unknown columns
+func mapStepColumns(headers []string, lookup map[string]string) []string {
+	columns := make([]string, len(headers))
+	for i, header := range headers {
+		columns[i] = lookup[normalizeHeader(header)]
+	}
+	return columns
+}
+
+func normalizeHeader(header string) string {
+	header = strings.ToLower(strings.TrimSpace(header))
+	header = strings.NewReplacer("_", " ", "-", " ", ".", "").Replace(header)
+	return strings.Join(strings.Fields(header), " ")
+}
+
+func isBlankRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultStepOrder returns the step fields in the column order of new step tables
+func DefaultStepOrder() []string {
+	return append([]string{}, defaultStepOrder...)
+}
+
+// StepCells returns the cell values of a step for the given column fields.
+// Columns that map to no step field are left empty.
+func StepCells(step models.TestStep, columns []string) []string {
+	cells := make([]string, len(columns))
+	for i, field := range columns {
+		switch field {
+		case stepFieldNumber:
+			cells[i] = strconv.Itoa(step.Number)
+		case stepFieldAction:
+			cells[i] = step.Action
+		case stepFieldExpectedResult:
+			cells[i] = step.ExpectedResult
+		case stepFieldActualResult:
+			cells[i] = step.ActualResult
+		case stepFieldStatus:
+			cells[i] = step.Status
+		case stepFieldScreenshot:
+			cells[i] = step.Screenshot
+		}
+	}
+	return cells
+}
+
+// tableWidth is the column count Notion reports, or that of the first row
+func tableWidth(table models.TableWithData) int {
+	if table.TableWidth > 0 {
+		return table.TableWidth
+	}
+	return len(table.Rows[0].Cells)
+}
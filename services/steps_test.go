@@ -0,0 +1,163 @@
+package services
+
+import (
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"reflect"
+	"testing"
+)
+
+func rows(cells ...[]string) []models.TableRow {
+	var result []models.TableRow
+	for _, c := range cells {
+		result = append(result, models.TableRow{Cells: c})
+	}
+	return result
+}
+
+func TestParseSteps(t *testing.T) {
+	lookup := StepColumnLookup(config.DefaultSchema())
+
+	tests := []struct {
+		name         string
+		table        models.TableWithData
+		wantSteps    []models.TestStep
+		wantWarnings []models.StepWarning
+	}{
+		{
+			name: "empty table",
+		},
+		{
+			name: "header maps columns by name and keeps unknown columns",
+			table: models.TableWithData{
+				HasColumnHeader: true,
+				Rows: rows(
+					[]string{"Expected", "Test Step", "Browser", "Step No"},
+					[]string{" Dashboard shown ", "Log in", "Firefox", "3."},
+				),
+			},
+			wantSteps: []models.TestStep{{
+				Number:         3,
+				Action:         "Log in",
+				ExpectedResult: "Dashboard shown",
+				Extra:          map[string]string{"Browser": "Firefox"},
+			}},
+		},
+		{
+			name: "missing step numbers count up",
+			table: models.TableWithData{
+				HasColumnHeader: true,
+				Rows: rows(
+					[]string{"Action", "Expected Result"},
+					[]string{"Open", "Opened"},
+					[]string{"", ""},
+					[]string{"Close", "Closed"},
+				),
+			},
+			wantSteps: []models.TestStep{
+				{Number: 1, Action: "Open", ExpectedResult: "Opened"},
+				{Number: 2, Action: "Close", ExpectedResult: "Closed"},
+			},
+		},
+		{
+			name: "header without action or expected result is not a step table",
+			table: models.TableWithData{
+				HasColumnHeader: true,
+				Rows: rows(
+					[]string{"Name", "Value"},
+					[]string{"browser", "firefox"},
+				),
+			},
+		},
+		{
+			name: "header-less table in the default order",
+			table: models.TableWithData{
+				TableWidth: 6,
+				Rows: rows(
+					[]string{"1", "Open", "Opened", "Opened", "Passed", "shot.png"},
+				),
+			},
+			wantSteps: []models.TestStep{{
+				Number:         1,
+				Action:         "Open",
+				ExpectedResult: "Opened",
+				ActualResult:   "Opened",
+				Status:         "Passed",
+				Screenshot:     "shot.png",
+			}},
+		},
+		{
+			name: "header-less table of another width is not a step table",
+			table: models.TableWithData{
+				TableWidth: 2,
+				Rows: rows(
+					[]string{"browser", "firefox"},
+					[]string{"os", "linux"},
+				),
+			},
+		},
+		{
+			name: "header-less width falls back to the first row",
+			table: models.TableWithData{
+				Rows: rows([]string{"browser", "firefox", "linux"}),
+			},
+		},
+		{
+			name: "bad rows are reported with their index",
+			table: models.TableWithData{
+				HasColumnHeader: true,
+				Rows: rows(
+					[]string{"Step", "Action", "Expected Result"},
+					[]string{"x", "Open", "Opened"},
+					[]string{"2", "Close"},
+					[]string{"3", "", ""},
+					[]string{"4", "", "Closed"},
+				),
+			},
+			wantSteps: []models.TestStep{{Number: 4, ExpectedResult: "Closed"}},
+			wantWarnings: []models.StepWarning{
+				{Row: 1, Message: `step number "x" is not a number`},
+				{Row: 2, Message: "row has 2 cells, expected 3"},
+				{Row: 3, Message: "row has neither an action nor an expected result"},
+			},
+		},
+		{
+			name: "row with neither action nor expected result",
+			table: models.TableWithData{
+				HasColumnHeader: true,
+				Rows: rows(
+					[]string{"Action", "Expected Result", "Status"},
+					[]string{"", "", "Passed"},
+				),
+			},
+			wantWarnings: []models.StepWarning{
+				{Row: 1, Message: "row has neither an action nor an expected result"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, warnings := ParseSteps(tt.table, lookup)
+			if !reflect.DeepEqual(steps, tt.wantSteps) {
+				t.Errorf("steps = %+v, want %+v", steps, tt.wantSteps)
+			}
+			if !reflect.DeepEqual(warnings, tt.wantWarnings) {
+				t.Errorf("warnings = %+v, want %+v", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestStepColumnLookupPrefersConfiguredNames(t *testing.T) {
+	schema := config.DefaultSchema()
+	schema.StepColumns = map[string][]string{stepFieldAction: {"Expected"}}
+
+	lookup := StepColumnLookup(schema)
+	if got := lookup["expected"]; got != stepFieldAction {
+		t.Errorf(`lookup["expected"] = %q, want %q`, got, stepFieldAction)
+	}
+	if got := lookup["action"]; got != "" {
+		t.Errorf(`lookup["action"] = %q, want the default names of action to be replaced`, got)
+	}
+}
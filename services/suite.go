@@ -0,0 +1,367 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"demo-notion-api/config"
+	"demo-notion-api/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrSuiteNotFound = errors.New("suite not found")
+	ErrPlanNotFound  = errors.New("plan not found")
+)
+
+type SuiteService struct {
+	notionService *NotionService
+	suites        []models.TestSuite
+	plans         []models.TestPlan
+}
+
+// NewSuiteService loads suite and plan definitions from cfg.SuitesFile.
+// A missing file is not an error; the service then has no suites or plans.
+func NewSuiteService(cfg *config.Config, notionService *NotionService) (*SuiteService, error) {
+	s := &SuiteService{notionService: notionService}
+
+	if cfg.SuitesFile == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(cfg.SuitesFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read suites file: %w", err)
+	}
+
+	var file models.SuitesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse suites file %s: %w", cfg.SuitesFile, err)
+	}
+
+	if err := validateSuitesFile(file); err != nil {
+		return nil, fmt.Errorf("invalid suites file %s: %w", cfg.SuitesFile, err)
+	}
+
+	s.suites = file.Suites
+	s.plans = file.Plans
+	return s, nil
+}
+
+// WithContext returns a copy of the service whose Notion calls carry ctx
+func (s *SuiteService) WithContext(ctx context.Context) *SuiteService {
+	scoped := *s
+	scoped.notionService = s.notionService.WithContext(ctx)
+	return &scoped
+}
+
+// ListSuites returns all configured suites
+func (s *SuiteService) ListSuites() []models.TestSuite {
+	return s.suites
+}
+
+// ListPlans returns all configured plans
+func (s *SuiteService) ListPlans() []models.TestPlan {
+	return s.plans
+}
+
+// GetSuiteTestCases resolves the test cases that belong to a suite
+func (s *SuiteService) GetSuiteTestCases(name string) (*models.SuiteTestCasesResponse, error) {
+	suite, err := s.findSuite(name)
+	if err != nil {
+		return nil, err
+	}
+
+	testCases, err := s.notionService.SearchTestCases()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SuiteTestCasesResponse{
+		Suite:     *suite,
+		TestCases: filterSuite(*suite, testCases),
+	}, nil
+}
+
+// GetPlanTestCases resolves the test cases of every suite referenced by a plan
+func (s *SuiteService) GetPlanTestCases(name string) (*models.PlanTestCasesResponse, error) {
+	plan, err := s.findPlan(name)
+	if err != nil {
+		return nil, err
+	}
+
+	testCases, err := s.notionService.SearchTestCases()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.planMembers(*plan, testCases)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PlanTestCasesResponse{
+		Plan:      *plan,
+		TestCases: resolved,
+	}, nil
+}
+
+// PlanMembers picks the test cases of every suite referenced by a plan from
+// already searched test cases
+func (s *SuiteService) PlanMembers(name string, testCases []models.TestCaseResponse) ([]models.TestCaseResponse, error) {
+	plan, err := s.findPlan(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.planMembers(*plan, testCases)
+}
+
+// GetPlanProgress computes aggregate progress for a plan and each of its suites
+func (s *SuiteService) GetPlanProgress(name string) (*models.PlanProgressResponse, error) {
+	plan, err := s.findPlan(name)
+	if err != nil {
+		return nil, err
+	}
+
+	testCases, err := s.notionService.SearchTestCases()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var planCases []models.TestCaseResponse
+	suites := []models.SuiteProgress{}
+	for _, suiteName := range plan.Suites {
+		suite, err := s.findSuite(suiteName)
+		if err != nil {
+			return nil, err
+		}
+		suiteCases := filterSuite(*suite, testCases)
+		suites = append(suites, models.SuiteProgress{
+			Suite:    suite.Name,
+			Progress: SummarizeProgress(s.notionService.Schema(), suiteCases),
+		})
+		for _, tc := range suiteCases {
+			if !seen[tc.PageID] {
+				seen[tc.PageID] = true
+				planCases = append(planCases, tc)
+			}
+		}
+	}
+
+	return &models.PlanProgressResponse{
+		Plan:     *plan,
+		Progress: SummarizeProgress(s.notionService.Schema(), planCases),
+		Suites:   suites,
+	}, nil
+}
+
+// SuitesFor returns the names of the suites a test case belongs to
+func (s *SuiteService) SuitesFor(tc models.TestCaseResponse) []string {
+	var names []string
+	for _, suite := range s.suites {
+		if suiteMatches(suite, tc) {
+			names = append(names, suite.Name)
+		}
+	}
+	return names
+}
+
+// NotionService returns the service suites are resolved against
+func (s *SuiteService) NotionService() *NotionService {
+	return s.notionService
+}
+
+// SummarizeProgress aggregates test case statuses into outcome counts
+func SummarizeProgress(schema *config.Schema, testCases []models.TestCaseResponse) models.ProgressSummary {
+	summary := models.ProgressSummary{
+		Total:    len(testCases),
+		ByStatus: make(map[string]int),
+	}
+
+	for _, tc := range testCases {
+		summary.ByStatus[tc.Status]++
+
+		switch schema.ClassifyStatus(tc.Status) {
+		case models.OutcomePassed:
+			summary.Passed++
+		case models.OutcomeFailed:
+			summary.Failed++
+		case models.OutcomeBlocked:
+			summary.Blocked++
+		case models.OutcomeSkipped:
+			summary.Skipped++
+		case models.OutcomeInProgress:
+			summary.InProgress++
+		default:
+			summary.NotRun++
+		}
+	}
+
+	summary.Executed = summary.Passed + summary.Failed + summary.Blocked + summary.Skipped
+	summary.Remaining = summary.Total - summary.Executed
+	if decided := summary.Passed + summary.Failed; decided > 0 {
+		summary.PassRate = float64(summary.Passed) / float64(decided)
+	}
+
+	return summary
+}
+
+// Helper methods
+
+func (s *SuiteService) findSuite(name string) (*models.TestSuite, error) {
+	for i := range s.suites {
+		if s.suites[i].Name == name {
+			return &s.suites[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSuiteNotFound, name)
+}
+
+func (s *SuiteService) findPlan(name string) (*models.TestPlan, error) {
+	for i := range s.plans {
+		if s.plans[i].Name == name {
+			return &s.plans[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrPlanNotFound, name)
+}
+
+func (s *SuiteService) planMembers(plan models.TestPlan, testCases []models.TestCaseResponse) ([]models.TestCaseResponse, error) {
+	seen := make(map[string]bool)
+	resolved := []models.TestCaseResponse{}
+	for _, suiteName := range plan.Suites {
+		suite, err := s.findSuite(suiteName)
+		if err != nil {
+			return nil, err
+		}
+		for _, tc := range filterSuite(*suite, testCases) {
+			if seen[tc.PageID] {
+				continue
+			}
+			seen[tc.PageID] = true
+			resolved = append(resolved, tc)
+		}
+	}
+	return resolved, nil
+}
+
+func validateSuitesFile(file models.SuitesFile) error {
+	suiteNames := make(map[string]bool)
+	for _, suite := range file.Suites {
+		if suite.Name == "" {
+			return errors.New("suite name is required")
+		}
+		if suiteNames[suite.Name] {
+			return fmt.Errorf("duplicate suite %q", suite.Name)
+		}
+		suiteNames[suite.Name] = true
+
+		if len(suite.Keys) == 0 && len(suite.KeyRanges) == 0 && suite.Filter == nil {
+			return fmt.Errorf("suite %q must define keys, key_ranges or filter", suite.Name)
+		}
+		for _, r := range suite.KeyRanges {
+			if r.From == "" || r.To == "" {
+				return fmt.Errorf("suite %q has a key range without from or to", suite.Name)
+			}
+		}
+	}
+
+	planNames := make(map[string]bool)
+	for _, plan := range file.Plans {
+		if plan.Name == "" {
+			return errors.New("plan name is required")
+		}
+		if planNames[plan.Name] {
+			return fmt.Errorf("duplicate plan %q", plan.Name)
+		}
+		planNames[plan.Name] = true
+
+		for _, suiteName := range plan.Suites {
+			if !suiteNames[suiteName] {
+				return fmt.Errorf("plan %q references unknown suite %q", plan.Name, suiteName)
+			}
+		}
+	}
+
+	return nil
+}
+
+func filterSuite(suite models.TestSuite, testCases []models.TestCaseResponse) []models.TestCaseResponse {
+	matched := []models.TestCaseResponse{}
+	for _, tc := range testCases {
+		if suiteMatches(suite, tc) {
+			matched = append(matched, tc)
+		}
+	}
+	return matched
+}
+
+func suiteMatches(suite models.TestSuite, tc models.TestCaseResponse) bool {
+	for _, key := range suite.Keys {
+		if key == tc.TestCaseKey {
+			return true
+		}
+	}
+
+	for _, r := range suite.KeyRanges {
+		if compareKeys(tc.TestCaseKey, r.From) >= 0 && compareKeys(tc.TestCaseKey, r.To) <= 0 {
+			return true
+		}
+	}
+
+	if suite.Filter != nil {
+		return filterMatches(*suite.Filter, tc)
+	}
+
+	return false
+}
+
+func filterMatches(filter models.SuiteFilter, tc models.TestCaseResponse) bool {
+	if len(filter.Statuses) > 0 && !containsFold(filter.Statuses, tc.Status) {
+		return false
+	}
+
+	for _, tag := range filter.Tags {
+		if !containsFold(tc.Tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compareKeys orders numeric keys numerically before all other keys, which
+// are ordered lexically. Numerically equal keys such as "01" and "1" fall back
+// to the lexical order, so that the order is total and sorts are stable.
+func compareKeys(a, b string) int {
+	an, errA := strconv.Atoi(a)
+	bn, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		if c := cmp.Compare(an, bn); c != 0 {
+			return c
+		}
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"demo-notion-api/models"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSuiteMatches(t *testing.T) {
+	tc := func(key, status string, tags ...string) models.TestCaseResponse {
+		return models.TestCaseResponse{TestCaseKey: key, PageID: "page-" + key, Status: status, Tags: tags}
+	}
+
+	tests := []struct {
+		name  string
+		suite models.TestSuite
+		tc    models.TestCaseResponse
+		want  bool
+	}{
+		{name: "listed key", suite: models.TestSuite{Keys: []string{"01001", "01003"}}, tc: tc("01003", ""), want: true},
+		{name: "unlisted key", suite: models.TestSuite{Keys: []string{"01001"}}, tc: tc("01002", ""), want: false},
+		{name: "keys match exactly", suite: models.TestSuite{Keys: []string{"1001"}}, tc: tc("01001", ""), want: false},
+
+		{name: "range start is inclusive", suite: models.TestSuite{KeyRanges: []models.KeyRange{{From: "01001", To: "01099"}}}, tc: tc("01001", ""), want: true},
+		{name: "range end is inclusive", suite: models.TestSuite{KeyRanges: []models.KeyRange{{From: "01001", To: "01099"}}}, tc: tc("01099", ""), want: true},
+		{name: "after the range", suite: models.TestSuite{KeyRanges: []models.KeyRange{{From: "01001", To: "01099"}}}, tc: tc("01100", ""), want: false},
+		{name: "ranges compare numerically", suite: models.TestSuite{KeyRanges: []models.KeyRange{{From: "9", To: "100"}}}, tc: tc("10", ""), want: true},
+		{name: "non-numeric ranges compare lexically", suite: models.TestSuite{KeyRanges: []models.KeyRange{{From: "AUTH-01", To: "AUTH-09"}}}, tc: tc("AUTH-05", ""), want: true},
+		{name: "non-numeric keys are after numeric ranges", suite: models.TestSuite{KeyRanges: []models.KeyRange{{From: "1", To: "99999"}}}, tc: tc("AUTH-05", ""), want: false},
+		{name: "second range", suite: models.TestSuite{KeyRanges: []models.KeyRange{{From: "01001", To: "01009"}, {From: "02001", To: "02009"}}}, tc: tc("02005", ""), want: true},
+
+		{name: "status filter ignores case", suite: models.TestSuite{Filter: &models.SuiteFilter{Statuses: []string{"failed", "blocked"}}}, tc: tc("01001", "Failed"), want: true},
+		{name: "status filter", suite: models.TestSuite{Filter: &models.SuiteFilter{Statuses: []string{"Failed"}}}, tc: tc("01001", "Passed"), want: false},
+		{name: "every tag is required", suite: models.TestSuite{Filter: &models.SuiteFilter{Tags: []string{"smoke", "auth"}}}, tc: tc("01001", "", "Smoke", "auth", "web"), want: true},
+		{name: "missing tag", suite: models.TestSuite{Filter: &models.SuiteFilter{Tags: []string{"smoke", "auth"}}}, tc: tc("01001", "", "smoke"), want: false},
+		{name: "status and tags", suite: models.TestSuite{Filter: &models.SuiteFilter{Statuses: []string{"Failed"}, Tags: []string{"smoke"}}}, tc: tc("01001", "Failed"), want: false},
+		{name: "empty filter matches everything", suite: models.TestSuite{Filter: &models.SuiteFilter{}}, tc: tc("01001", ""), want: true},
+
+		{name: "keys or filter", suite: models.TestSuite{Keys: []string{"01001"}, Filter: &models.SuiteFilter{Statuses: []string{"Failed"}}}, tc: tc("01001", "Passed"), want: true},
+		{name: "no selectors", suite: models.TestSuite{}, tc: tc("01001", "Passed"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suiteMatches(tt.suite, tt.tc); got != tt.want {
+				t.Errorf("suiteMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanMembers(t *testing.T) {
+	s := &SuiteService{
+		suites: []models.TestSuite{
+			{Name: "login", KeyRanges: []models.KeyRange{{From: "01001", To: "01099"}}},
+			{Name: "smoke", Filter: &models.SuiteFilter{Tags: []string{"smoke"}}},
+		},
+		plans: []models.TestPlan{
+			{Name: "release", Suites: []string{"smoke", "login"}},
+			{Name: "broken", Suites: []string{"missing"}},
+		},
+	}
+	testCases := []models.TestCaseResponse{
+		{TestCaseKey: "01001", PageID: "a"},
+		{TestCaseKey: "02001", PageID: "b", Tags: []string{"smoke"}},
+		{TestCaseKey: "01002", PageID: "c", Tags: []string{"smoke"}},
+		{TestCaseKey: "03001", PageID: "d"},
+	}
+
+	members, err := s.PlanMembers("release", testCases)
+	if err != nil {
+		t.Fatalf("PlanMembers() error = %v", err)
+	}
+	var keys []string
+	for _, tc := range members {
+		keys = append(keys, tc.TestCaseKey)
+	}
+	if want := []string{"02001", "01002", "01001"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("members = %v, want %v in suite order without duplicates", keys, want)
+	}
+
+	if want := []string{"login", "smoke"}; !reflect.DeepEqual(s.SuitesFor(testCases[2]), want) {
+		t.Errorf("SuitesFor() = %v, want %v", s.SuitesFor(testCases[2]), want)
+	}
+
+	if _, err := s.PlanMembers("unknown", testCases); !errors.Is(err, ErrPlanNotFound) {
+		t.Errorf("PlanMembers(unknown) error = %v, want ErrPlanNotFound", err)
+	}
+	if _, err := s.PlanMembers("broken", testCases); !errors.Is(err, ErrSuiteNotFound) {
+		t.Errorf("PlanMembers(broken) error = %v, want ErrSuiteNotFound", err)
+	}
+}
+
+func TestValidateSuitesFile(t *testing.T) {
+	keys := []string{"01001"}
+
+	tests := []struct {
+		name    string
+		file    models.SuitesFile
+		wantErr string
+	}{
+		{name: "valid", file: models.SuitesFile{
+			Suites: []models.TestSuite{{Name: "login", Keys: keys}, {Name: "smoke", Filter: &models.SuiteFilter{}}},
+			Plans:  []models.TestPlan{{Name: "release", Suites: []string{"login", "smoke"}}},
+		}},
+		{name: "unnamed suite", file: models.SuitesFile{Suites: []models.TestSuite{{Keys: keys}}}, wantErr: "suite name is required"},
+		{name: "duplicate suite", file: models.SuitesFile{Suites: []models.TestSuite{{Name: "a", Keys: keys}, {Name: "a", Keys: keys}}}, wantErr: `duplicate suite "a"`},
+		{name: "suite without selectors", file: models.SuitesFile{Suites: []models.TestSuite{{Name: "a"}}}, wantErr: "must define keys, key_ranges or filter"},
+		{name: "open key range", file: models.SuitesFile{Suites: []models.TestSuite{{Name: "a", KeyRanges: []models.KeyRange{{From: "01001"}}}}}, wantErr: "key range without from or to"},
+		{name: "unnamed plan", file: models.SuitesFile{Plans: []models.TestPlan{{}}}, wantErr: "plan name is required"},
+		{name: "duplicate plan", file: models.SuitesFile{Plans: []models.TestPlan{{Name: "p"}, {Name: "p"}}}, wantErr: `duplicate plan "p"`},
+		{name: "unknown suite in plan", file: models.SuitesFile{Plans: []models.TestPlan{{Name: "p", Suites: []string{"a"}}}}, wantErr: `references unknown suite "a"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSuitesFile(tt.file)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateSuitesFile() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateSuitesFile() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"demo-notion-api/config"
+	"demo-notion-api/logging"
+	"demo-notion-api/models"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// SyncService periodically reads every project and records a new version of
+// each test case whose last edited time moved since the previous sync
+type SyncService struct {
+	projects *ProjectRegistry
+	history  *HistoryService
+	interval time.Duration
+
+	mu         sync.Mutex
+	listeners  []func()
+	lastSynced map[string]time.Time
+}
+
+// NewSyncService reads the sync interval from cfg.SyncInterval
+func NewSyncService(cfg *config.Config, projects *ProjectRegistry, history *HistoryService) (*SyncService, error) {
+	interval, err := time.ParseDuration(cfg.SyncInterval)
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("invalid sync interval %q", cfg.SyncInterval)
+	}
+
+	return &SyncService{
+		projects:   projects,
+		history:    history,
+		interval:   interval,
+		lastSynced: make(map[string]time.Time),
+	}, nil
+}
+
+// Start syncs right away and then once per interval in the background
+func (s *SyncService) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			ctx := logging.WithRequestID(context.Background(), "sync-"+logging.NewRequestID())
+			if err := s.Sync(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to sync test cases", slog.Any("error", err))
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// OnSync registers fn to run after every sync, e.g. to rebuild data derived from the history
+func (s *SyncService) OnSync(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// LastSynced returns the time at which the last sync of each project finished.
+// Projects that could not be listed are missing until their first sync.
+func (s *SyncService) LastSynced() map[string]time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lastSynced := make(map[string]time.Time, len(s.lastSynced))
+	for name, synced := range s.lastSynced {
+		lastSynced[name] = synced
+	}
+	return lastSynced
+}
+
+// Sync records new versions for every project. A project or test case that
+// cannot be read is skipped and the last error is returned after the rest are synced.
+func (s *SyncService) Sync(ctx context.Context) error {
+	var failed error
+
+	for _, info := range s.projects.List() {
+		if err := s.syncProject(ctx, info.Name); err != nil {
+			failed = fmt.Errorf("project %s: %w", info.Name, err)
+		}
+	}
+
+	s.mu.Lock()
+	listeners := append([]func(){}, s.listeners...)
+	s.mu.Unlock()
+	for _, fn := range listeners {
+		fn()
+	}
+
+	return failed
+}
+
+// Helper methods
+
+func (s *SyncService) syncProject(ctx context.Context, name string) error {
+	project, err := s.projects.Get(name)
+	if err != nil {
+		return err
+	}
+	notionService := project.NotionService.WithContext(ctx)
+
+	testCases, err := notionService.SearchTestCases()
+	if err != nil {
+		return err
+	}
+
+	var failed error
+	for _, tc := range testCases {
+		if s.history.HasVersion(name, tc) {
+			continue
+		}
+
+		blocks, err := notionService.GetBlockTree(tc.PageID)
+		if err != nil {
+			failed = fmt.Errorf("test case %s: %w", tc.TestCaseKey, err)
+			continue
+		}
+
+		// A partly read test case would record its missing tables as removed;
+		// leave it for the next sync instead
+		detailed := notionService.GetDetailedTestCaseFromBlocks(tc, blocks)
+		if len(detailed.Errors) > 0 {
+			failed = fmt.Errorf("test case %s: %s", tc.TestCaseKey, detailed.Errors[0].Message)
+			continue
+		}
+
+		version := models.TestCaseVersion{
+			LastEdited:     tc.LastEdited,
+			LastEditedBy:   notionService.GetUserName(tc.LastEditedBy),
+			LastEditedByID: tc.LastEditedBy,
+			SyncedAt:       time.Now().UTC(),
+			TestCase:       detailed,
+			Blocks:         blocks,
+		}
+		if _, err := s.history.Record(name, version); err != nil {
+			failed = fmt.Errorf("test case %s: %w", tc.TestCaseKey, err)
+		}
+	}
+
+	// Test cases that failed are retried next time; the project itself is up to date
+	s.mu.Lock()
+	s.lastSynced[name] = time.Now()
+	s.mu.Unlock()
+
+	return failed
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"demo-notion-api/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestSyncReadsEachPageOnce(t *testing.T) {
+	text := func(content string) []map[string]any { return []map[string]any{{"plain_text": content}} }
+	responses := map[string]any{
+		"/search": map[string]any{"results": []any{map[string]any{
+			"object":           "page",
+			"id":               "page-01001",
+			"last_edited_time": "2026-01-05T10:00:00.000Z",
+			"properties": map[string]any{
+				"Test Case Name": map[string]any{"type": "title", "title": text("TC_01001 Login")},
+			},
+		}}},
+		"/blocks/page-01001/children": map[string]any{"results": []any{
+			map[string]any{"object": "block", "id": "intro", "type": "paragraph", "paragraph": map[string]any{"rich_text": text("Intro")}},
+			map[string]any{"object": "block", "id": "steps", "type": "table", "has_children": true, "table": map[string]any{"table_width": 3, "has_column_header": true}},
+		}},
+		"/blocks/steps": map[string]any{"object": "block", "id": "steps", "type": "table", "has_children": true, "table": map[string]any{"table_width": 3, "has_column_header": true}},
+		"/blocks/steps/children": map[string]any{"results": []any{
+			map[string]any{"object": "block", "id": "r1", "type": "table_row", "table_row": map[string]any{"cells": []any{text("Step"), text("Action"), text("Expected Result")}}},
+			map[string]any{"object": "block", "id": "r2", "type": "table_row", "table_row": map[string]any{"cells": []any{text("1"), text("Open"), text("Opened")}}},
+		}},
+	}
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{NotionAPIURL: server.URL, DataDir: t.TempDir(), SyncInterval: "1h"}
+	projects, err := NewProjectRegistry(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history, err := NewHistoryService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	syncService, err := NewSyncService(cfg, projects, history)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := syncService.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	if n := requests["/blocks/page-01001/children"]; n != 1 {
+		t.Errorf("page blocks read %d times, want once", n)
+	}
+
+	version, err := history.Version(config.DefaultProjectName, "01001", 1)
+	if err != nil {
+		t.Fatalf("Version() error = %v", err)
+	}
+	if len(version.Blocks) != 2 {
+		t.Errorf("blocks = %+v, want the page's two blocks", version.Blocks)
+	}
+	table := stepTable(version.TestCase)
+	if table == nil || len(table.Steps) != 1 || table.Steps[0].Action != "Open" {
+		t.Errorf("tables = %+v, want the step table", version.TestCase.Tables)
+	}
+}
@@ -0,0 +1,23 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// untracedPaths are polled by probes and scrapers and would only add noise
+var untracedPaths = map[string]bool{
+	"/api/health": true,
+	"/api/ready":  true,
+	"/metrics":    true,
+}
+
+// Middleware starts a server span per request, continuing the trace of an
+// incoming traceparent header
+func Middleware() gin.HandlerFunc {
+	return otelgin.Middleware(ServiceName, otelgin.WithFilter(func(r *http.Request) bool {
+		return !untracedPaths[r.URL.Path]
+	}))
+}